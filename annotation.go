@@ -2,8 +2,10 @@ package annotation
 
 import (
 	"fmt"
-	"strconv"
+	"math/big"
+	"sort"
 	"strings"
+	"time"
 )
 
 // ValueType is a string that tells the type of the parsed parameter
@@ -16,16 +18,50 @@ const (
 	// INT represents an int type parameter
 	INT ValueType = "int"
 
-	// FLOAT represents a float type parameter
+	// INT64 is used by parameter definitions to require an int parameter that fits in an int64
+	INT64 ValueType = "int64"
+
+	// UINT64 is used by parameter definitions to require an int parameter that fits in an uint64
+	UINT64 ValueType = "uint64"
+
+	// BIGINT is used by parameter definitions to require an int parameter of any size
+	BIGINT ValueType = "bigint"
+
+	// FLOAT represents a float type parameter,
+	// parameter definitions use it to require a float parameter that fits in a float64
 	FLOAT ValueType = "float"
 
+	// BIGFLOAT is used by parameter definitions to require a float parameter of any size
+	BIGFLOAT ValueType = "bigfloat"
+
+	// DURATION represents a duration type parameter (e.x `timeout=1h30m`)
+	DURATION ValueType = "duration"
+
 	// BOOL represents a bool type parameter
 	BOOL ValueType = "bool"
 
+	// NULL represents an explicit null parameter (e.x `default=null`), a missing parameter is UNKNOWN
+	NULL ValueType = "null"
+
+	// IDENT represents an identifier type parameter (e.x `method=GET`, `level=log.Debug`)
+	IDENT ValueType = "ident"
+
+	// LIST represents a list type parameter (e.x `[1, 2, 3]`)
+	LIST ValueType = "list"
+
+	// MAP represents a map type parameter (e.x `{"key": "value", other: 2}`)
+	MAP ValueType = "map"
+
+	// ANNOTATION represents a nested annotation type parameter (e.x `@Auth(optional=false)`)
+	ANNOTATION ValueType = "annotation"
+
 	// UNKNOWN represents an unknown type parameter (usually if the parameter does not exist)
 	UNKNOWN ValueType = "unknown"
 )
 
+// DefaultParameter is the name of the parameter that holds a positional value e.x `@Path("/users")`
+const DefaultParameter = "value"
+
 // Value is the interface that wraps the annotation parameter
 // tha parameter can be represented in all of the below types,
 // if the parameter value is not convertible to a type the value
@@ -33,8 +69,20 @@ const (
 type Value interface {
 	String() string
 	Int() int
+	Int64() int64
+	Uint64() uint64
+	BigInt() *big.Int
 	Float() float64
+	BigFloat() *big.Float
+	Duration() time.Duration
 	Bool() bool
+	List() []Value
+	Map() map[string]Value
+	// Keys returns the keys of a map parameter in the order they were written
+	Keys() []string
+	Annotation() *Annotation
+	// Range returns the start and end position of the value in the parsed string
+	Range() Range
 	Type() ValueType
 }
 
@@ -42,6 +90,18 @@ type Value interface {
 type Annotation struct {
 	Name       string
 	parameters map[string]attrValue
+
+	// order has the names of the parameters in the order they were set
+	order []string
+
+	// marker is true if the annotation was written without parentheses e.x `@Deprecated`
+	marker bool
+
+	// rng is the range of the annotation in the parsed string
+	rng Range
+
+	// keys has the range of the parameter keys in the parsed string
+	keys map[string]Range
 }
 
 // NewAnnotation creates a new Annotation.
@@ -64,7 +124,11 @@ func (a *Annotation) Get(name string) Value {
 	return attrValue{}
 }
 
+// Set sets the value of a parameter, a new parameter is added after the ones that are already set.
 func (a *Annotation) Set(name string, value attrValue) {
+	if _, ok := a.parameters[name]; !ok {
+		a.order = append(a.order, name)
+	}
 	if a.parameters != nil {
 		a.parameters[name] = value
 	} else {
@@ -74,16 +138,55 @@ func (a *Annotation) Set(name string, value attrValue) {
 	}
 }
 
-// String returns the annotation string
+// Keys returns the names of the parameters in the order they were written.
+func (a *Annotation) Keys() []string {
+	if len(a.order) == 0 {
+		return nil
+	}
+	return append([]string(nil), a.order...)
+}
+
+// Range returns the start and end position of the annotation in the parsed string.
+func (a *Annotation) Range() Range {
+	return a.rng
+}
+
+// KeyRange returns the start and end position of the parameter name in the parsed string,
+// the range is not valid if the parameter does not exist or it is a positional value.
+func (a *Annotation) KeyRange(name string) Range {
+	return a.keys[name]
+}
+
+func (a *Annotation) setKeyRange(name string, r Range) {
+	if a.keys == nil {
+		a.keys = map[string]Range{}
+	}
+	a.keys[name] = r
+}
+
+// String returns the annotation string, the parameters are in the order they were written.
 func (a *Annotation) String() string {
-	s := fmt.Sprintf("@%s(", a.Name)
-	for k, p := range a.parameters {
-		switch p.Type() {
-		case STRING:
-			s += fmt.Sprintf("%s=%s, ", k, strconv.Quote(p.String()))
-		default:
-			s += fmt.Sprintf("%s=%s, ", k, p.String())
-		}
+	return a.format(false)
+}
+
+// SortedString returns the annotation string with the parameters sorted by name,
+// the parameters of the nested annotations are sorted as well.
+func (a *Annotation) SortedString() string {
+	return a.format(true)
+}
+
+// format returns the annotation string, if sorted is true the parameters are sorted by name.
+func (a *Annotation) format(sorted bool) string {
+	if a.marker && len(a.parameters) == 0 {
+		return "@" + a.Name
+	}
+	keys := a.Keys()
+	if sorted {
+		sort.Strings(keys)
+	}
+	s := make([]string, len(keys))
+	for i, k := range keys {
+		s[i] = k + "=" + a.parameters[k].source(sorted)
 	}
-	return strings.TrimSuffix(s, ", ") + ")"
+	return fmt.Sprintf("@%s(%s)", a.Name, strings.Join(s, ", "))
 }
@@ -169,6 +169,7 @@ func TestAnnotation_String(t *testing.T) {
 	type fields struct {
 		Name       string
 		parameters map[string]attrValue
+		marker     bool
 	}
 	tests := []struct {
 		name   string
@@ -217,7 +218,56 @@ func TestAnnotation_String(t *testing.T) {
 					},
 				},
 			},
-			want: "@MyAnnotation(float=2.2000)",
+			want: "@MyAnnotation(float=2.2)",
+		},
+		{
+			name: "Should write a small float with an exponent",
+			fields: fields{
+				Name: "Limit",
+				parameters: map[string]attrValue{
+					"eps": {
+						F: pointerFloat(1e-9),
+					},
+				},
+			},
+			want: "@Limit(eps=1e-09)",
+		},
+		{
+			name: "Should write a large float with an exponent",
+			fields: fields{
+				Name: "Limit",
+				parameters: map[string]attrValue{
+					"max": {
+						F: pointerFloat(1e308),
+					},
+				},
+			},
+			want: "@Limit(max=1e+308)",
+		},
+		{
+			name: "Should write a float without a fraction as a float",
+			fields: fields{
+				Name: "Limit",
+				parameters: map[string]attrValue{
+					"ratio": {
+						F: pointerFloat(2),
+					},
+				},
+			},
+			want: "@Limit(ratio=2.0)",
+		},
+		{
+			name: "Should write every digit of a float that a float64 does not keep",
+			fields: fields{
+				Name: "Math",
+				parameters: map[string]attrValue{
+					"pi": {
+						F:  pointerFloat(3.141592653589793),
+						BF: pointerBigFloat("3.14159265358979323846264338327950288"),
+					},
+				},
+			},
+			want: "@Math(pi=3.14159265358979323846264338327950288)",
 		},
 		{
 			name: "Should return the correct string representation of the annotation",
@@ -231,12 +281,37 @@ func TestAnnotation_String(t *testing.T) {
 			},
 			want: "@MyAnnotation(bool=true)",
 		},
+		{
+			name: "Should return the marker annotation without parentheses",
+			fields: fields{
+				Name:       "Deprecated",
+				parameters: map[string]attrValue{},
+				marker:     true,
+			},
+			want: "@Deprecated",
+		},
+		{
+			name: "Should return the parentheses if a marker annotation has parameters",
+			fields: fields{
+				Name: "Deprecated",
+				parameters: map[string]attrValue{
+					"since": {
+						Str: pointerString("1.2"),
+					},
+				},
+				marker: true,
+			},
+			want: "@Deprecated(since=\"1.2\")",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ad := &Annotation{
-				Name:       tt.fields.Name,
-				parameters: tt.fields.parameters,
+				Name:   tt.fields.Name,
+				marker: tt.fields.marker,
+			}
+			for k, v := range tt.fields.parameters {
+				ad.Set(k, v)
 			}
 			if got := ad.String(); got != tt.want {
 				t.Errorf("Annotation.String() = %v, want %v", got, tt.want)
@@ -244,3 +319,52 @@ func TestAnnotation_String(t *testing.T) {
 		})
 	}
 }
+
+func TestAnnotation_Keys(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want []string
+	}{
+		{
+			name: "Should return nothing for a marker annotation",
+			s:    `@Deprecated`,
+		},
+		{
+			name: "Should return the parameters in the order they were written",
+			s:    `@Route(path="/users", method=GET, auth=@Auth, cache=5m)`,
+			want: []string{"path", "method", "auth", "cache"},
+		},
+		{
+			name: "Should return the default parameter of a positional value",
+			s:    `@Path("/users")`,
+			want: []string{DefaultParameter},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := Parse(tt.s)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := a.Keys(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Annotation.Keys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnotation_String_order(t *testing.T) {
+	a, err := Parse(`@Route(path="/users", method=GET, auth=@Auth(roles=["admin"], optional=true), cache={ttl: 5m})`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := `@Route(path="/users", method=GET, auth=@Auth(roles=["admin"], optional=true), cache={"ttl": 5m0s})`
+	if got := a.String(); got != want {
+		t.Errorf("Annotation.String() = %v, want %v", got, want)
+	}
+	want = `@Route(auth=@Auth(optional=true, roles=["admin"]), cache={"ttl": 5m0s}, method=GET, path="/users")`
+	if got := a.SortedString(); got != want {
+		t.Errorf("Annotation.SortedString() = %v, want %v", got, want)
+	}
+}
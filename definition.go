@@ -1,6 +1,9 @@
 package annotation
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Definition describes the Annotation definition.
 type Definition struct {
@@ -24,6 +27,18 @@ type ParameterDefinition struct {
 
 	// tp shows the required type of the annotation
 	tp ValueType
+
+	// definition is used to check the parameter if it is a nested annotation
+	definition *Definition
+
+	// nullable tells if the parameter can be set to null
+	nullable bool
+
+	// allowed has the list of values the parameter can have, any value is allowed if it is empty
+	allowed []string
+
+	// positional tells if the parameter receives the positional value e.x `@Path("/users")`
+	positional bool
 }
 
 // NewParameterDefinition returns a new parameter definition
@@ -35,6 +50,40 @@ func NewParameterDefinition(name string, required bool, parameterType ValueType)
 	}
 }
 
+// NewDefaultParameterDefinition returns a new parameter definition for the default parameter,
+// the default parameter receives the positional value of the annotation e.x `@Path("/users")`.
+func NewDefaultParameterDefinition(name string, required bool, parameterType ValueType) ParameterDefinition {
+	return ParameterDefinition{
+		name:       name,
+		required:   required,
+		tp:         parameterType,
+		positional: true,
+	}
+}
+
+// NewAnnotationParameterDefinition returns a new parameter definition for a nested annotation parameter,
+// the nested annotation is checked against the given definition.
+func NewAnnotationParameterDefinition(name string, required bool, definition Definition) ParameterDefinition {
+	return ParameterDefinition{
+		name:       name,
+		required:   required,
+		tp:         ANNOTATION,
+		definition: &definition,
+	}
+}
+
+// Nullable returns a copy of the parameter definition that allows the parameter to be null.
+func (p ParameterDefinition) Nullable() ParameterDefinition {
+	p.nullable = true
+	return p
+}
+
+// OneOf returns a copy of the parameter definition that only allows the given values e.x `GET`, `POST`.
+func (p ParameterDefinition) OneOf(values ...string) ParameterDefinition {
+	p.allowed = values
+	return p
+}
+
 // NewDefinition creates a new Annotation Definition.
 func NewDefinition(name string, allowUnknownParameters bool, parameters ...ParameterDefinition) Definition {
 	return Definition{
@@ -56,12 +105,45 @@ func (d Definition) allowParameter(name string) bool {
 	return false
 }
 
-// Check checks if the annotation matches the definition
+// Normalize returns a copy of the annotation where the positional value
+// is renamed to the default parameter of the definition.
+func (d *Definition) Normalize(annotation Annotation) Annotation {
+	for _, p := range d.parameters {
+		if !p.positional || p.name == DefaultParameter {
+			continue
+		}
+		if _, ok := annotation.parameters[DefaultParameter]; !ok {
+			return annotation
+		}
+		if _, ok := annotation.parameters[p.name]; ok {
+			return annotation
+		}
+		normalized := NewAnnotation(annotation.Name)
+		normalized.marker = annotation.marker
+		normalized.rng = annotation.rng
+		for _, k := range annotation.order {
+			v := annotation.parameters[k]
+			if r, ok := annotation.keys[k]; ok {
+				normalized.setKeyRange(k, r)
+			}
+			if k == DefaultParameter {
+				k = p.name
+			}
+			normalized.Set(k, v)
+		}
+		return normalized
+	}
+	return annotation
+}
+
+// Check checks if the annotation matches the definition,
+// the positional value is checked against the default parameter of the definition.
 func (d *Definition) Check(annotation Annotation) error {
+	annotation = d.Normalize(annotation)
 	if d.name != annotation.Name {
 		return fmt.Errorf("annotation Name `%s` does not match the definition Name %s", annotation.Name, d.name)
 	}
-	for k := range annotation.parameters {
+	for _, k := range annotation.order {
 		if !d.allowParameter(k) {
 			return fmt.Errorf("unknown parameter: `%s` in `@%s()` Annotation", k, d.name)
 		}
@@ -82,7 +164,13 @@ func (p *ParameterDefinition) checkParameter(annotation Annotation) error {
 	if parameter.Type() == UNKNOWN {
 		return nil
 	}
-	if p.tp != parameter.Type() {
+	if parameter.Type() == NULL {
+		if p.nullable {
+			return nil
+		}
+		return fmt.Errorf("the `%s` parameter for @%s() Annotation can not be null", p.name, annotation.Name)
+	}
+	if !p.matches(parameter) {
 		return fmt.Errorf(
 			"the `%s` parameter for @%s() Annotation should have be of type `%s`",
 			p.name,
@@ -90,5 +178,51 @@ func (p *ParameterDefinition) checkParameter(annotation Annotation) error {
 			p.tp,
 		)
 	}
+	if len(p.allowed) > 0 && !p.allows(parameter.String()) {
+		return fmt.Errorf(
+			"the `%s` parameter for @%s() Annotation should be one of `%s`, got `%s`",
+			p.name,
+			annotation.Name,
+			strings.Join(p.allowed, "`, `"),
+			parameter.String(),
+		)
+	}
+	if p.definition != nil {
+		if err := p.definition.Check(*parameter.Annotation()); err != nil {
+			return fmt.Errorf("the `%s` parameter for @%s() Annotation is invalid: %v", p.name, annotation.Name, err)
+		}
+	}
 	return nil
 }
+
+func (p *ParameterDefinition) allows(value string) bool {
+	for _, a := range p.allowed {
+		if a == value {
+			return true
+		}
+	}
+	return false
+}
+
+// matches checks if the parameter value has the type required by the definition,
+// int values also have to fit in the required int size and float values in a float64 unless BIGFLOAT is required.
+func (p *ParameterDefinition) matches(parameter Value) bool {
+	switch p.tp {
+	case INT:
+		i := parameter.BigInt()
+		return parameter.Type() == INT && i.IsInt64() && int64(int(i.Int64())) == i.Int64()
+	case INT64:
+		return parameter.Type() == INT && parameter.BigInt().IsInt64()
+	case UINT64:
+		return parameter.Type() == INT && parameter.BigInt().IsUint64()
+	case BIGINT:
+		return parameter.Type() == INT
+	case FLOAT:
+		// Float returns 0 for a float that does not fit in a float64
+		return parameter.Type() == FLOAT && (parameter.Float() != 0 || parameter.BigFloat().Sign() == 0)
+	case BIGFLOAT:
+		return parameter.Type() == FLOAT
+	default:
+		return p.tp == parameter.Type()
+	}
+}
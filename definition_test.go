@@ -39,6 +39,108 @@ func TestNewParameterDefinition(t *testing.T) {
 	}
 }
 
+func TestNewDefaultParameterDefinition(t *testing.T) {
+	type args struct {
+		name          string
+		required      bool
+		parameterType ValueType
+	}
+	tests := []struct {
+		name string
+		args args
+		want ParameterDefinition
+	}{
+		{
+			name: "Should return new default parameter definition",
+			args: args{
+				name:          "path",
+				required:      true,
+				parameterType: STRING,
+			},
+			want: ParameterDefinition{
+				name:       "path",
+				required:   true,
+				tp:         STRING,
+				positional: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewDefaultParameterDefinition(tt.args.name, tt.args.required, tt.args.parameterType); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewDefaultParameterDefinition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAnnotationParameterDefinition(t *testing.T) {
+	type args struct {
+		name       string
+		required   bool
+		definition Definition
+	}
+	tests := []struct {
+		name string
+		args args
+		want ParameterDefinition
+	}{
+		{
+			name: "Should return new annotation parameter definition",
+			args: args{
+				name:       "auth",
+				required:   true,
+				definition: NewDefinition("Auth", true),
+			},
+			want: ParameterDefinition{
+				name:     "auth",
+				required: true,
+				tp:       ANNOTATION,
+				definition: &Definition{
+					name:                   "Auth",
+					allowUnknownParameters: true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewAnnotationParameterDefinition(tt.args.name, tt.args.required, tt.args.definition); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewAnnotationParameterDefinition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParameterDefinition_Nullable(t *testing.T) {
+	p := NewParameterDefinition("default", false, STRING)
+	want := ParameterDefinition{
+		name:     "default",
+		required: false,
+		tp:       STRING,
+		nullable: true,
+	}
+	if got := p.Nullable(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParameterDefinition.Nullable() = %v, want %v", got, want)
+	}
+	if p.nullable {
+		t.Errorf("ParameterDefinition.Nullable() should not change the original definition")
+	}
+}
+
+func TestParameterDefinition_OneOf(t *testing.T) {
+	p := NewParameterDefinition("method", true, IDENT)
+	want := ParameterDefinition{
+		name:     "method",
+		required: true,
+		tp:       IDENT,
+		allowed:  []string{"GET", "POST"},
+	}
+	if got := p.OneOf("GET", "POST"); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParameterDefinition.OneOf() = %v, want %v", got, want)
+	}
+}
+
 func TestNewDefinition(t *testing.T) {
 	type args struct {
 		name                   string
@@ -143,6 +245,104 @@ func TestDefinition_allowParameter(t *testing.T) {
 	}
 }
 
+func TestDefinition_Normalize(t *testing.T) {
+	path := ParameterDefinition{
+		name:       "path",
+		required:   true,
+		tp:         STRING,
+		positional: true,
+	}
+	tests := []struct {
+		name       string
+		parameters []ParameterDefinition
+		annotation Annotation
+		want       Annotation
+	}{
+		{
+			name:       "Should rename the positional value to the default parameter",
+			parameters: []ParameterDefinition{path},
+			annotation: Annotation{
+				Name: "Path",
+				parameters: map[string]attrValue{
+					DefaultParameter: {
+						Str: pointerString("/users"),
+					},
+				},
+				order: []string{DefaultParameter},
+			},
+			want: Annotation{
+				Name: "Path",
+				parameters: map[string]attrValue{
+					"path": {
+						Str: pointerString("/users"),
+					},
+				},
+				order: []string{"path"},
+			},
+		},
+		{
+			name:       "Should not change the annotation if the default parameter is already set",
+			parameters: []ParameterDefinition{path},
+			annotation: Annotation{
+				Name: "Path",
+				parameters: map[string]attrValue{
+					"path": {
+						Str: pointerString("/users"),
+					},
+				},
+				order: []string{"path"},
+			},
+			want: Annotation{
+				Name: "Path",
+				parameters: map[string]attrValue{
+					"path": {
+						Str: pointerString("/users"),
+					},
+				},
+				order: []string{"path"},
+			},
+		},
+		{
+			name: "Should not change the annotation if the definition has no default parameter",
+			parameters: []ParameterDefinition{
+				{
+					name: "path",
+					tp:   STRING,
+				},
+			},
+			annotation: Annotation{
+				Name: "Path",
+				parameters: map[string]attrValue{
+					DefaultParameter: {
+						Str: pointerString("/users"),
+					},
+				},
+				order: []string{DefaultParameter},
+			},
+			want: Annotation{
+				Name: "Path",
+				parameters: map[string]attrValue{
+					DefaultParameter: {
+						Str: pointerString("/users"),
+					},
+				},
+				order: []string{DefaultParameter},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Definition{
+				name:       "Path",
+				parameters: tt.parameters,
+			}
+			if got := d.Normalize(tt.annotation); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Definition.Normalize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDefinition_Check(t *testing.T) {
 	type fields struct {
 		name                   string
@@ -179,6 +379,7 @@ func TestDefinition_Check(t *testing.T) {
 							Str: pointerString("test"),
 						},
 					},
+					order: []string{"param"},
 				},
 			},
 			wantErr: false,
@@ -198,6 +399,7 @@ func TestDefinition_Check(t *testing.T) {
 							Str: pointerString("test"),
 						},
 					},
+					order: []string{"param"},
 				},
 			},
 			wantErr: false,
@@ -223,6 +425,7 @@ func TestDefinition_Check(t *testing.T) {
 							Str: pointerString("test"),
 						},
 					},
+					order: []string{"param"},
 				},
 			},
 			wantErr: true,
@@ -242,6 +445,7 @@ func TestDefinition_Check(t *testing.T) {
 							Str: pointerString("test"),
 						},
 					},
+					order: []string{"param"},
 				},
 			},
 			wantErr: true,
@@ -267,6 +471,60 @@ func TestDefinition_Check(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should check the positional value against the default parameter",
+			fields: fields{
+				name:                   "Path",
+				allowUnknownParameters: false,
+				parameters: []ParameterDefinition{
+					{
+						name:       "path",
+						required:   true,
+						tp:         STRING,
+						positional: true,
+					},
+				},
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Path",
+					parameters: map[string]attrValue{
+						DefaultParameter: {
+							Str: pointerString("/users"),
+						},
+					},
+					order: []string{DefaultParameter},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error if the positional value does not match the default parameter",
+			fields: fields{
+				name:                   "Path",
+				allowUnknownParameters: false,
+				parameters: []ParameterDefinition{
+					{
+						name:       "path",
+						required:   true,
+						tp:         STRING,
+						positional: true,
+					},
+				},
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Path",
+					parameters: map[string]attrValue{
+						DefaultParameter: {
+							I: pointerInt(1),
+						},
+					},
+					order: []string{DefaultParameter},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -284,9 +542,12 @@ func TestDefinition_Check(t *testing.T) {
 
 func TestParameterDefinition_checkParameter(t *testing.T) {
 	type fields struct {
-		name     string
-		required bool
-		tp       ValueType
+		name       string
+		required   bool
+		tp         ValueType
+		definition *Definition
+		nullable   bool
+		allowed    []string
 	}
 	type args struct {
 		annotation Annotation
@@ -365,13 +626,334 @@ func TestParameterDefinition_checkParameter(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should not return error if the nested annotation matches the nested definition",
+			fields: fields{
+				name:     "auth",
+				required: true,
+				tp:       ANNOTATION,
+				definition: &Definition{
+					name: "Auth",
+					parameters: []ParameterDefinition{
+						{
+							name:     "optional",
+							required: true,
+							tp:       BOOL,
+						},
+					},
+				},
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Endpoint",
+					parameters: map[string]attrValue{
+						"auth": {
+							A: &Annotation{
+								Name: "Auth",
+								parameters: map[string]attrValue{
+									"optional": {
+										VFalse: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error if the nested annotation does not match the nested definition",
+			fields: fields{
+				name:     "auth",
+				required: true,
+				tp:       ANNOTATION,
+				definition: &Definition{
+					name: "Auth",
+					parameters: []ParameterDefinition{
+						{
+							name:     "optional",
+							required: true,
+							tp:       BOOL,
+						},
+					},
+				},
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Endpoint",
+					parameters: map[string]attrValue{
+						"auth": {
+							A: &Annotation{
+								Name:       "Auth",
+								parameters: map[string]attrValue{},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should not return error if the parameter is null and null is allowed",
+			fields: fields{
+				name:     "default",
+				required: true,
+				tp:       STRING,
+				nullable: true,
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Field",
+					parameters: map[string]attrValue{
+						"default": {
+							Null: true,
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error if the parameter is null and null is not allowed",
+			fields: fields{
+				name:     "default",
+				required: false,
+				tp:       STRING,
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Field",
+					parameters: map[string]attrValue{
+						"default": {
+							Null: true,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should not return error if the int fits in an int64",
+			fields: fields{
+				name:     "quota",
+				required: true,
+				tp:       INT64,
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Quota",
+					parameters: map[string]attrValue{
+						"quota": {
+							I: pointerInt(-1),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error if the int does not fit in an int64",
+			fields: fields{
+				name:     "quota",
+				required: true,
+				tp:       INT64,
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Quota",
+					parameters: map[string]attrValue{
+						"quota": {
+							B: pointerBigInt("18446744073709551615"),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should not return error if the int fits in an uint64",
+			fields: fields{
+				name:     "quota",
+				required: true,
+				tp:       UINT64,
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Quota",
+					parameters: map[string]attrValue{
+						"quota": {
+							B: pointerBigInt("18446744073709551615"),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error if a negative int is required to fit in an uint64",
+			fields: fields{
+				name:     "quota",
+				required: true,
+				tp:       UINT64,
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Quota",
+					parameters: map[string]attrValue{
+						"quota": {
+							I: pointerInt(-1),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should not return error for any int size if a big int is required",
+			fields: fields{
+				name:     "quota",
+				required: true,
+				tp:       BIGINT,
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Quota",
+					parameters: map[string]attrValue{
+						"quota": {
+							B: pointerBigInt("123456789012345678901234567890"),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error if a float that does not fit in a float64 is required to be a float",
+			fields: fields{
+				name:     "ratio",
+				required: true,
+				tp:       FLOAT,
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Ratio",
+					parameters: map[string]attrValue{
+						"ratio": {
+							BF: pointerBigFloat("1e-400"),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should not return error for any float size if a big float is required",
+			fields: fields{
+				name:     "ratio",
+				required: true,
+				tp:       BIGFLOAT,
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Ratio",
+					parameters: map[string]attrValue{
+						"ratio": {
+							BF: pointerBigFloat("1e999"),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error if an int that does not fit in an int64 is required to be an int",
+			fields: fields{
+				name:     "quota",
+				required: true,
+				tp:       INT,
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Quota",
+					parameters: map[string]attrValue{
+						"quota": {
+							B: pointerBigInt("18446744073709551616"),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return error if a duration is required but the parameter is a string",
+			fields: fields{
+				name:     "timeout",
+				required: true,
+				tp:       DURATION,
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Cache",
+					parameters: map[string]attrValue{
+						"timeout": {
+							Str: pointerString("5s"),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should not return error if the identifier is allowed",
+			fields: fields{
+				name:     "method",
+				required: true,
+				tp:       IDENT,
+				allowed:  []string{"GET", "POST"},
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Route",
+					parameters: map[string]attrValue{
+						"method": {
+							Ident: pointerString("GET"),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should return error if the identifier is not allowed",
+			fields: fields{
+				name:     "method",
+				required: true,
+				tp:       IDENT,
+				allowed:  []string{"GET", "POST"},
+			},
+			args: args{
+				annotation: Annotation{
+					Name: "Route",
+					parameters: map[string]attrValue{
+						"method": {
+							Ident: pointerString("GTE"),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &ParameterDefinition{
-				name:     tt.fields.name,
-				required: tt.fields.required,
-				tp:       tt.fields.tp,
+				name:       tt.fields.name,
+				required:   tt.fields.required,
+				tp:         tt.fields.tp,
+				definition: tt.fields.definition,
+				nullable:   tt.fields.nullable,
+				allowed:    tt.fields.allowed,
 			}
 			if err := p.checkParameter(tt.args.annotation); (err != nil) != tt.wantErr {
 				t.Errorf("ParameterDefinition.checkParameter() error = %v, wantErr %v", err, tt.wantErr)
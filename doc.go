@@ -1,6 +1,7 @@
 // Package annotation is used to parse and check java style annotation (e.x @SomeAnnotation(param="value"))
 //
-// The package can only be used for string that only contain the annotation, it can not find the annotation in an arbitrary string.
+// Parse and ParseAll can only be used for string that only contain annotations,
+// Find can be used to find the annotations in an arbitrary string.
 //
 // Usage
 //	annotationString := `@Annotation(
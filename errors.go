@@ -0,0 +1,244 @@
+package annotation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseError is the error returned when an annotation can not be parsed,
+// use errors.As to get it from the error returned by Parse.
+type ParseError struct {
+	// Pos is the position of the problem.
+	Pos Position
+
+	// Message describes the problem e.x "unexpected `)`".
+	Message string
+
+	// Expected lists what was expected at the position, it is empty if that is not known.
+	Expected []string
+
+	// Unexpected is the offending text, it is empty at the end of the input.
+	Unexpected string
+
+	// Err is the cause of the error if there is one e.x a *LimitError.
+	Err error
+
+	// line is the source line of the problem and column the byte column in it starting at 0,
+	// they are used to render the snippet.
+	line   string
+	column int
+}
+
+// Error returns the error in the form `line:column: message, expected ...`.
+func (e *ParseError) Error() string {
+	s := e.Message
+	if len(e.Expected) > 0 {
+		s += ", expected " + strings.Join(e.Expected, " or ")
+	}
+	if e.Pos.IsValid() {
+		s = e.Pos.String() + ": " + s
+	}
+	return s
+}
+
+// Unwrap returns the cause of the error so errors.As can find e.x a *LimitError.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Snippet returns the source line of the problem with a caret under the problem e.x
+//
+//	@Get(path=)
+//	          ^
+func (e *ParseError) Snippet() string {
+	// the caret is padded with a space for every character before it, the tabs are kept
+	var pad strings.Builder
+	for _, r := range e.line[:e.column] {
+		if r == '\t' {
+			pad.WriteByte('\t')
+		} else {
+			pad.WriteByte(' ')
+		}
+	}
+	return e.line + "\n" + pad.String() + "^"
+}
+
+// Limit is a limit of a Parser.
+type Limit string
+
+const (
+	// LimitLength is the limit set with MaxLength
+	LimitLength Limit = "length"
+
+	// LimitParameters is the limit set with MaxParameters
+	LimitParameters Limit = "parameters"
+
+	// LimitDepth is the limit set with MaxDepth
+	LimitDepth Limit = "depth"
+
+	// LimitStringLength is the limit set with MaxStringLength
+	LimitStringLength Limit = "string length"
+
+	// LimitAnnotations is the limit set with MaxAnnotations
+	LimitAnnotations Limit = "annotations"
+)
+
+// LimitError is the cause of the ParseError returned when a limit of the Parser is exceeded,
+// use errors.As to get it from the error returned by Parse.
+type LimitError struct {
+	Limit Limit
+	Max   int
+}
+
+// Error returns the limit that is exceeded.
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s limit of %d exceeded", e.Limit, e.Max)
+}
+
+// DuplicateError is the cause of the ParseError returned when a parameter is set more than once,
+// the ParseError is at the second parameter and First is the position of the first one.
+type DuplicateError struct {
+	Name  string
+	First Position
+}
+
+// Error returns the parameter that is set more than once.
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("parameter `%s` is already set at %s", e.Name, e.First)
+}
+
+// VariableError is the cause of the ParseError returned when a variable reference of a string value
+// can not be replaced because the variable is not set.
+type VariableError struct {
+	Name string
+}
+
+// Error returns the variable that is not set.
+func (e *VariableError) Error() string {
+	return fmt.Sprintf("variable `%s` is not set", e.Name)
+}
+
+// ErrorList is the error returned by ParseAll when some of the annotations can not be parsed,
+// it has the errors in the order they are found.
+type ErrorList []*ParseError
+
+// Error returns the first error and the number of other errors.
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", l[0], len(l)-1)
+}
+
+// As sets target to the first error in the list that matches it, it lets errors.As find e.x a *ParseError
+// or a *LimitError in the list with Go versions older than 1.20 that do not use Unwrap() []error.
+func (l ErrorList) As(target interface{}) bool {
+	for _, e := range l {
+		if errors.As(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns the errors so errors.As can find a ParseError in the list.
+func (l ErrorList) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, e := range l {
+		errs[i] = e
+	}
+	return errs
+}
+
+// Err returns the list as an error, it returns nil if the list is empty.
+func (l ErrorList) Err() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+// errorAt returns a ParseError for the problem at the byte offset,
+// the unexpected text is the word or character at the offset.
+func (s *source) errorAt(offset int, message string, expected ...string) *ParseError {
+	e := s.newError(offset, message, expected...)
+	rest := s.text[s.index(offset):]
+	n := 0
+	for n < len(rest) {
+		r, size := utf8.DecodeRuneInString(rest[n:])
+		if !isWordRune(r) || r == '.' || r == '-' || r == '+' {
+			break
+		}
+		n += size
+	}
+	if n == 0 && rest != "" {
+		_, n = utf8.DecodeRuneInString(rest)
+	}
+	e.Unexpected = rest[:n]
+	return e
+}
+
+// unexpectedError returns a ParseError for the unexpected text at the byte offset.
+func (s *source) unexpectedError(offset int, expected ...string) *ParseError {
+	e := s.errorAt(offset, "", expected...)
+	e.Message = unexpectedMessage(e.Unexpected)
+	return e
+}
+
+// newError returns a ParseError at the byte offset.
+func (s *source) newError(offset int, message string, expected ...string) *ParseError {
+	i := s.index(offset)
+	n := sort.Search(len(s.lines), func(j int) bool { return s.lines[j] > i }) - 1
+	line, end := s.lines[n], len(s.text)
+	if n+1 < len(s.lines) {
+		end = s.lines[n+1] - 1
+	}
+	text := strings.TrimRight(s.text[line:end], "\r")
+	column := i - line
+	if column > len(text) {
+		// the problem is at the `\r` of the line
+		column = len(text)
+	}
+	return &ParseError{
+		Pos:      s.position(i - s.start),
+		Message:  message,
+		Expected: expected,
+		line:     text,
+		column:   column,
+	}
+}
+
+// limitError returns a ParseError at the byte offset caused by a LimitError.
+func (s *source) limitError(offset int, limit Limit, max int, message string) *ParseError {
+	e := s.newError(offset, fmt.Sprintf(message, max))
+	e.Err = &LimitError{Limit: limit, Max: max}
+	return e
+}
+
+// index returns the index in the text of a byte offset, offsets past the end of the text return its length.
+func (s *source) index(offset int) int {
+	if s.start+offset > len(s.text) {
+		return len(s.text)
+	}
+	return s.start + offset
+}
+
+// notFoundError returns the error for a string that does not start with an annotation.
+func (s *source) notFoundError() *ParseError {
+	text := s.text[s.start:]
+	offset := len(text) - len(strings.TrimLeft(text, " \t\r\n"))
+	return s.errorAt(offset, "annotation not found in string", "`@`")
+}
+
+func unexpectedMessage(unexpected string) string {
+	if unexpected == "" {
+		return "unexpected end of input"
+	}
+	return fmt.Sprintf("unexpected `%s`", unexpected)
+}
@@ -0,0 +1,252 @@
+package annotation
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name       string
+		s          string
+		want       string
+		unexpected string
+		expected   []string
+		snippet    string
+	}{
+		{
+			name:       "Should report a missing value",
+			s:          `@Route(path=)`,
+			want:       "1:13: unexpected `)`, expected a value",
+			unexpected: ")",
+			expected:   []string{"a value"},
+			snippet:    "@Route(path=)\n            ^",
+		},
+		{
+			name:     "Should report the end of the input",
+			s:        `@Route(path="/users"`,
+			want:     "1:21: unexpected end of input, expected `,` or `)`",
+			expected: []string{"`,`", "`)`"},
+			snippet:  "@Route(path=\"/users\"\n                    ^",
+		},
+		{
+			name:     "Should report the end of the input right after the last token",
+			s:        "@Get(path=\"/users\"\n\n",
+			want:     "1:19: unexpected end of input, expected `,` or `)`",
+			expected: []string{"`,`", "`)`"},
+			snippet:  "@Get(path=\"/users\"\n                  ^",
+		},
+		{
+			name:       "Should report an unexpected string",
+			s:          `@Route("/users" "/groups")`,
+			want:       "1:17: unexpected `\"/groups\"`, expected `,` or `)`",
+			unexpected: `"/groups"`,
+			expected:   []string{"`,`", "`)`"},
+			snippet:    "@Route(\"/users\" \"/groups\")\n                ^",
+		},
+		{
+			name:       "Should report a missing name",
+			s:          `@(path="/users")`,
+			want:       "1:2: unexpected `(`, expected a name",
+			unexpected: "(",
+			expected:   []string{"a name"},
+			snippet:    "@(path=\"/users\")\n ^",
+		},
+		{
+			name:       "Should report a missing map colon",
+			s:          `@Cache(headers={tenant})`,
+			want:       "1:23: unexpected `}`, expected `:`",
+			unexpected: "}",
+			expected:   []string{"`:`"},
+			snippet:    "@Cache(headers={tenant})\n                      ^",
+		},
+		{
+			name:    "Should report the end of a line that ends with CRLF",
+			s:       "@A(x=\"abc\r\n)",
+			want:    "1:11: literal not terminated",
+			snippet: "@A(x=\"abc\n         ^",
+		},
+		{
+			name:       "Should put the caret under the problem after non-ASCII text",
+			s:          `@A(x="日本語", y=)`,
+			want:       "1:21: unexpected `)`, expected a value",
+			unexpected: ")",
+			expected:   []string{"a value"},
+			snippet:    "@A(x=\"日本語\", y=)\n              ^",
+		},
+		{
+			name:    "Should report a lexer error",
+			s:       `@Route(path="/users)`,
+			want:    "1:21: literal not terminated",
+			snippet: "@Route(path=\"/users)\n                    ^",
+		},
+		{
+			name:    "Should report a number out of range at the number",
+			s:       `@Limit(max=1e99999999999999)`,
+			want:    "1:12: number `1e99999999999999` is out of range",
+			snippet: "@Limit(max=1e99999999999999)\n           ^",
+		},
+		{
+			name:    "Should report an invalid duration on its line and keep the tabs",
+			s:       "@Retry(\n\tdelay=5 s\n)",
+			want:    "2:8: invalid duration `5 s`",
+			snippet: "\tdelay=5 s\n\t      ^",
+		},
+		{
+			name:    "Should report an invalid duration unit",
+			s:       "@Retry(\n\tdelay=5x\n)",
+			want:    "2:8: invalid duration `5x`",
+			snippet: "\tdelay=5x\n\t      ^",
+		},
+		{
+			name:    "Should report a duplicate map key at the second key",
+			s:       `@Cache(headers={a: 1, a: 2})`,
+			want:    "1:23: duplicate key `a` in map",
+			snippet: "@Cache(headers={a: 1, a: 2})\n                      ^",
+		},
+		{
+			name:       "Should report a string without annotation",
+			s:          `  Route()`,
+			want:       "1:3: annotation not found in string, expected `@`",
+			unexpected: "Route",
+			expected:   []string{"`@`"},
+			snippet:    "  Route()\n  ^",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.s)
+			var got *ParseError
+			if !errors.As(err, &got) {
+				t.Fatalf("Parse() error = %v, want a ParseError", err)
+			}
+			if got.Error() != tt.want {
+				t.Errorf("ParseError.Error() = %v, want %v", got.Error(), tt.want)
+			}
+			if got.Unexpected != tt.unexpected {
+				t.Errorf("ParseError.Unexpected = %v, want %v", got.Unexpected, tt.unexpected)
+			}
+			if !reflect.DeepEqual(got.Expected, tt.expected) {
+				t.Errorf("ParseError.Expected = %v, want %v", got.Expected, tt.expected)
+			}
+			if got.Snippet() != tt.snippet {
+				t.Errorf("ParseError.Snippet() = \n%v\nwant\n%v", got.Snippet(), tt.snippet)
+			}
+		})
+	}
+}
+
+func TestParseAll_errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		s           string
+		annotations []string
+		errors      []string
+	}{
+		{
+			name:        "Should report a missing parenthesis at the end",
+			s:           "@Get(\"/users\")\n@Auth(roles=[\"admin\"]",
+			annotations: []string{"Get"},
+			errors:      []string{"2:22: unexpected end of input, expected `,` or `)`"},
+		},
+		{
+			name:        "Should skip a malformed annotation up to its closing parenthesis",
+			s:           "@Get(path=)\n@Auth(roles=[\"admin\"])\n@Cache(ttl==1)\n@Deprecated",
+			annotations: []string{"Auth", "Deprecated"},
+			errors: []string{
+				"1:11: unexpected `)`, expected a value",
+				"3:12: unexpected `=`, expected a value",
+			},
+		},
+		{
+			name:        "Should skip an annotation that is not closed up to the next annotation",
+			s:           "@Get(path=\"/users\"\n@Auth(roles=[\"admin\"])",
+			annotations: []string{"Auth"},
+			errors:      []string{"1:19: unexpected end of input, expected `,` or `)`"},
+		},
+		{
+			name:        "Should skip text up to the next annotation",
+			s:           "@Get(\"/users\")\nsome text\n@Auth\n@1",
+			annotations: []string{"Get", "Auth"},
+			errors: []string{
+				"2:1: unexpected `some`, expected `@`",
+				"4:2: unexpected `1`, expected a name",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAll(tt.s)
+			var names []string
+			for _, a := range got {
+				names = append(names, a.Name)
+			}
+			if !reflect.DeepEqual(names, tt.annotations) {
+				t.Errorf("ParseAll() = %v, want %v", names, tt.annotations)
+			}
+			var list ErrorList
+			if !errors.As(err, &list) {
+				t.Fatalf("ParseAll() error = %v, want an ErrorList", err)
+			}
+			var errs []string
+			for _, e := range list {
+				errs = append(errs, e.Error())
+			}
+			if !reflect.DeepEqual(errs, tt.errors) {
+				t.Errorf("ParseAll() errors = %v, want %v", errs, tt.errors)
+			}
+			var pe *ParseError
+			if !errors.As(err, &pe) || pe != list[0] {
+				t.Errorf("errors.As() = %v, want the first error", pe)
+			}
+		})
+	}
+}
+
+func TestErrorList_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		l    ErrorList
+		want string
+	}{
+		{
+			name: "Should return the error",
+			l:    ErrorList{{Pos: Position{Line: 1, Column: 5}, Message: "unexpected `)`"}},
+			want: "1:5: unexpected `)`",
+		},
+		{
+			name: "Should return the first error and the number of other errors",
+			l: ErrorList{
+				{Pos: Position{Line: 1, Column: 5}, Message: "unexpected `)`"},
+				{Pos: Position{Line: 2, Column: 3}, Message: "unexpected `=`"},
+				{Pos: Position{Line: 3, Column: 1}, Message: "unexpected `x`"},
+			},
+			want: "1:5: unexpected `)` (and 2 more errors)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.l.Error(); got != tt.want {
+				t.Errorf("ErrorList.Error() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorList_As(t *testing.T) {
+	limit := &ParseError{Message: "annotation is longer than 10 bytes", Err: &LimitError{Limit: LimitLength, Max: 10}}
+	l := ErrorList{{Message: "unexpected `)`"}, limit}
+	var pe *ParseError
+	if !l.As(&pe) || pe != l[0] {
+		t.Errorf("ErrorList.As() = %v, want the first error", pe)
+	}
+	var le *LimitError
+	if !l.As(&le) || le != limit.Err {
+		t.Errorf("ErrorList.As() = %v, want the limit error", le)
+	}
+	var de *DuplicateError
+	if l.As(&de) {
+		t.Errorf("ErrorList.As() = %v, want false", de)
+	}
+}
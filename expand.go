@@ -0,0 +1,86 @@
+package annotation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// expand replaces the variable references of s, the string value of the current token,
+// if the Parser has a lookup.
+func (p *parser) expand(s string) (string, error) {
+	if p.lookup == nil || !strings.Contains(s, "${") {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		j := strings.Index(s[i:], "${")
+		if j < 0 {
+			b.WriteString(s[i:])
+			break
+		}
+		j += i
+		if j > i && s[j-1] == '$' {
+			// `$${` is written as `${`
+			b.WriteString(s[i : j-1])
+			b.WriteString("${")
+			i = j + 2
+			continue
+		}
+		b.WriteString(s[i:j])
+		end := strings.IndexByte(s[j:], '}')
+		if end < 0 {
+			return "", p.src.newError(p.reference(s, j), "variable reference not terminated", "`}`")
+		}
+		end += j
+		name, def, hasDefault := strings.Cut(s[j+2:end], ":-")
+		if !isVariableName(name) {
+			return "", p.src.newError(p.reference(s, j), fmt.Sprintf("invalid variable name `%s`", name))
+		}
+		v, ok := p.lookup(name)
+		switch {
+		case hasDefault && v == "":
+			v = def
+		case !ok:
+			e := p.src.newError(p.reference(s, j), fmt.Sprintf("variable `%s` is not set", name))
+			e.Err = &VariableError{Name: name}
+			return "", e
+		}
+		b.WriteString(v)
+		i = end + 1
+	}
+	return b.String(), nil
+}
+
+// reference returns the offset in the parsed text of the variable reference at position i of s,
+// the string value of the current token. The references are matched by their order
+// because the escapes of the quoted text make the value shorter than the text,
+// the offset of the token is returned if the reference is not found.
+func (p *parser) reference(s string, i int) int {
+	n := strings.Count(s[:i], "${")
+	text := p.s[p.tok.start:p.tok.end]
+	offset := 0
+	for ; n >= 0; n-- {
+		j := strings.Index(text[offset:], "${")
+		if j < 0 {
+			return p.tok.start
+		}
+		offset += j
+		if n > 0 {
+			offset += 2
+		}
+	}
+	return p.tok.start + offset
+}
+
+// isVariableName tells if s is a valid variable name e.x `DB_URL`.
+func isVariableName(s string) bool {
+	if s == "" || isDigit(s[0]) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c != '_' && !isDigit(c) && ('a' > lower(c) || lower(c) > 'z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,113 @@
+package annotation
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParser_Parse_expand(t *testing.T) {
+	lookup := MapLookup(map[string]string{
+		"DB_URL":  "postgres://db/users",
+		"DB_USER": "admin",
+		"EMPTY":   "",
+	})
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "Should replace a variable",
+			s:    `@Datasource(url="${DB_URL}")`,
+			want: "postgres://db/users",
+		},
+		{
+			name: "Should replace several variables in a text",
+			s:    `@Datasource(url="${DB_USER}@${DB_URL}?ssl=true")`,
+			want: "admin@postgres://db/users?ssl=true",
+		},
+		{
+			name: "Should use the default of a variable that is not set",
+			s:    `@Datasource(url="${DB_HOST:-localhost}:${DB_PORT:-5432}")`,
+			want: "localhost:5432",
+		},
+		{
+			name: "Should use the default of an empty variable",
+			s:    `@Datasource(url="${EMPTY:-none}")`,
+			want: "none",
+		},
+		{
+			name: "Should replace the variables of raw strings",
+			s:    "@Datasource(url=`${DB_URL}`)",
+			want: "postgres://db/users",
+		},
+		{
+			name: "Should keep the text that is not a reference",
+			s:    `@Price(value="$5 or $${DB_URL}")`,
+			want: "$5 or ${DB_URL}",
+		},
+		{
+			name:    "Should reject a variable that is not set",
+			s:       `@Datasource(url="${DB_USER}@${DB_HOST}")`,
+			wantErr: "1:29: variable `DB_HOST` is not set",
+		},
+		{
+			name:    "Should report the position of a reference after escapes",
+			s:       `@Datasource(url="\"${DB_HOST}\"")`,
+			wantErr: "1:20: variable `DB_HOST` is not set",
+		},
+		{
+			name:    "Should reject a variable in a nested value",
+			s:       `@Datasource(hosts=["${DB_URL}", "${DB_REPLICA}"])`,
+			wantErr: "1:34: variable `DB_REPLICA` is not set",
+		},
+		{
+			name:    "Should reject a reference that is not terminated",
+			s:       `@Datasource(url="${DB_URL")`,
+			wantErr: "1:18: variable reference not terminated, expected `}`",
+		},
+		{
+			name:    "Should reject an invalid variable name",
+			s:       `@Datasource(url="${DB-URL}")`,
+			wantErr: "1:18: invalid variable name `DB-URL`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewParser(Expand(lookup)).Parse(tt.s)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tt.wantErr {
+				t.Fatalf("Parser.Parse() error = %v, want %v", gotErr, tt.wantErr)
+			}
+			if err == nil {
+				if got := a.Get(a.Keys()[0]).String(); got != tt.want {
+					t.Errorf("Parser.Parse() value = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParser_Parse_expandError(t *testing.T) {
+	_, err := NewParser(Expand(MapLookup(nil))).Parse(`@Datasource(url="${DB_URL}")`)
+	var got *VariableError
+	want := &VariableError{Name: "DB_URL"}
+	if !errors.As(err, &got) || !reflect.DeepEqual(got, want) {
+		t.Errorf("Parser.Parse() error = %v, want %v", got, want)
+	}
+}
+
+func TestParse_withoutExpand(t *testing.T) {
+	a, err := Parse(`@Datasource(url="${DB_URL}")`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := a.Get("url").String(); got != "${DB_URL}" {
+		t.Errorf("Parse() url = %v, want ${DB_URL}", got)
+	}
+}
@@ -0,0 +1,322 @@
+package annotation
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Match is an annotation found in an arbitrary string.
+type Match struct {
+	Annotation Annotation
+
+	// Offset is the byte offset of the `@` that starts the annotation.
+	Offset int
+
+	// End is the byte offset right after the annotation.
+	End int
+}
+
+// Find finds every annotation in an arbitrary string e.x a doc comment that mixes text and annotations.
+//
+// Annotations with parentheses e.x `@Get("/users")` are found anywhere in the string,
+// marker annotations e.x `@Deprecated` are only found at the start of a line.
+// An `@` that follows a word (e.x `a@b.com`), or is inside a "quoted" or `code` text is skipped,
+// text that looks like an annotation but can not be parsed is skipped as well.
+// The time Find takes is linear in the length of the string whatever the string is.
+func Find(s string) []Match {
+	return defaultParser.Find(s)
+}
+
+// Find finds every annotation in an arbitrary string the same way as the package level Find.
+func (p *Parser) Find(s string) []Match {
+	return p.FindAt(s, Position{})
+}
+
+// FindAt finds every annotation in an arbitrary string the same way as Find,
+// the positions of the annotations are reported as if the string started at base.
+// The Offset and End of the matches are byte offsets in s.
+func FindAt(s string, base Position) []Match {
+	return defaultParser.FindAt(s, base)
+}
+
+// FindAt finds every annotation in an arbitrary string the same way as the package level FindAt.
+func (p *Parser) FindAt(s string, base Position) []Match {
+	matches, _ := p.find(s, base, false)
+	return matches
+}
+
+// FindAll finds every annotation in an arbitrary string the same way as FindAt,
+// it also returns an ErrorList with the errors of the annotations that can not be parsed.
+// An annotation with parentheses e.x `@Get(path=)` or `@Get(` and a marker annotation at the start of a line
+// that can not be parsed are errors, the other text that FindAt skips e.x `a@b.com` is not.
+// An annotation found after MaxAnnotations annotations is an error caused by a *LimitError and the search stops there.
+func FindAll(s string, base Position) ([]Match, error) {
+	return defaultParser.FindAll(s, base)
+}
+
+// FindAll finds every annotation in an arbitrary string the same way as the package level FindAll.
+func (p *Parser) FindAll(s string, base Position) ([]Match, error) {
+	matches, errs := p.find(s, base, true)
+	return matches, errs.Err()
+}
+
+// find finds the annotations of s, if report is true the errors of the annotations that can not be parsed are returned.
+func (p *Parser) find(s string, base Position, report bool) ([]Match, ErrorList) {
+	src := newSource(s, base)
+	parens := &parentheses{s: s}
+	var matches []Match
+	var errs ErrorList
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '"', '`':
+			i = skipQuoted(s, i, true)
+			continue
+		case '@':
+			m, ok, err := p.findAt(src, parens, i, report)
+			if ok && p.maxAnnotations > 0 && len(matches) == p.maxAnnotations {
+				if report {
+					errs = append(errs, src.limitError(i, LimitAnnotations, p.maxAnnotations, "there are more than %d annotations"))
+				}
+				return matches, errs
+			}
+			if ok {
+				matches = append(matches, m)
+			} else if err != nil {
+				errs = append(errs, err)
+			}
+			if m.End > i {
+				i = m.End
+				continue
+			}
+		}
+		i++
+	}
+	return matches, errs
+}
+
+// findAt tries to parse the annotation that starts at the `@` in position i.
+// If the annotation has parentheses but can not be parsed ok is false and the End of the match is set anyway,
+// the text up to it is skipped so the nested annotations are not parsed again and again.
+// The parse error is only returned if report is true, the text of an annotation whose parentheses are not closed
+// is only parsed to get the error.
+func (p *Parser) findAt(src *source, parens *parentheses, i int, report bool) (m Match, ok bool, err *ParseError) {
+	s := src.text
+	if i > 0 {
+		r, _ := utf8.DecodeLastRuneInString(s[:i])
+		if isWordRune(r) {
+			return Match{}, false, nil
+		}
+	}
+	end := i + 1
+	for end < len(s) {
+		r, size := utf8.DecodeRuneInString(s[end:])
+		if !isWordRune(r) || r == '.' || r == '-' || r == '+' {
+			break
+		}
+		end += size
+	}
+	if end == i+1 {
+		return Match{}, false, nil
+	}
+	// the parentheses can be written after white space e.x `@Get ("/users")` as Parse allows it
+	if j := skipSpace(s, end); j < len(s) && s[j] == '(' {
+		closed := parens.skip(j)
+		if closed < 0 {
+			if report {
+				_, parseErr := p.parseIn(src, i, nextAnnotation(s, i))
+				err, _ = parseErr.(*ParseError)
+			}
+			return Match{}, false, err
+		}
+		end = closed
+	} else if !atLineStart(s, i) {
+		return Match{}, false, nil
+	}
+	a, parseErr := p.parseIn(src, i, end)
+	if parseErr != nil {
+		if report {
+			err, _ = parseErr.(*ParseError)
+		}
+		return Match{End: end}, false, err
+	}
+	return Match{Annotation: *a, Offset: i, End: end}, true, nil
+}
+
+// annotationEnd returns the position right after the annotation that starts at the `@` in position i,
+// if the annotation has no name or its parentheses are not closed it ends at the next `@`.
+func annotationEnd(parens *parentheses, i int) int {
+	end, ok := closedEnd(parens, i)
+	if !ok {
+		return nextAnnotation(parens.s, i)
+	}
+	return end
+}
+
+// closedEnd returns the position right after the annotation that starts at the `@` in position i,
+// ok is false if the annotation has no name or its parentheses are not closed.
+func closedEnd(parens *parentheses, i int) (end int, ok bool) {
+	s := parens.s
+	end = i + 1
+	for end < len(s) {
+		r, size := utf8.DecodeRuneInString(s[end:])
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		end += size
+	}
+	if end == i+1 {
+		return 0, false
+	}
+	if j := skipSpace(s, end); j < len(s) && s[j] == '(' {
+		if end = parens.skip(j); end < 0 {
+			return 0, false
+		}
+	}
+	return end, true
+}
+
+// nextAnnotation returns the position of the next `@` after i that is not in a quoted text,
+// or the length of s if there is none.
+func nextAnnotation(s string, i int) int {
+	for i++; i < len(s); {
+		switch s[i] {
+		case '"', '`':
+			i = skipQuoted(s, i, false)
+			continue
+		case '@':
+			return i
+		}
+		i++
+	}
+	return len(s)
+}
+
+// skipSpace returns the position of the first character after i that is not white space.
+func skipSpace(s string, i int) int {
+	for i < len(s) && isSpace(s[i]) {
+		i++
+	}
+	return i
+}
+
+// parentheses finds where the parentheses of a string are closed,
+// it remembers every parentheses it went through so the text of a string is not scanned again and again
+// when many annotations are not closed e.x `@A( @A( @A(`.
+type parentheses struct {
+	s string
+
+	// offset is the offset of s in the whole text, the positions in end are positions in the whole text
+	// so they stay valid when a Reader drops the text it parsed already.
+	offset int
+
+	// end is the position right after the closing parenthesis of the parenthesis at a position,
+	// or -1 if it is not closed.
+	end map[int]int
+}
+
+// skip returns the position in s right after the parentheses that open at i,
+// or -1 if they are not closed.
+func (p *parentheses) skip(i int) int {
+	if end, ok := p.end[p.offset+i]; ok {
+		return p.local(end)
+	}
+	if p.end == nil {
+		p.end = map[int]int{}
+	}
+	s := p.s
+	var open []int
+scan:
+	for j := i; j < len(s); {
+		switch s[j] {
+		case '"', '\'', '`':
+			j = skipQuoted(s, j, false)
+			continue
+		case '(':
+			if end, ok := p.end[p.offset+j]; ok {
+				if end < 0 {
+					break scan
+				}
+				j = end - p.offset
+				continue
+			}
+			open = append(open, j)
+		case ')':
+			if len(open) == 0 {
+				break
+			}
+			p.end[p.offset+open[len(open)-1]] = p.offset + j + 1
+			open = open[:len(open)-1]
+			if len(open) == 0 {
+				return j + 1
+			}
+		}
+		j++
+	}
+	for _, o := range open {
+		p.end[p.offset+o] = -1
+	}
+	return -1
+}
+
+// local returns the position in s of a position in end.
+func (p *parentheses) local(end int) int {
+	if end < 0 {
+		return end
+	}
+	return end - p.offset
+}
+
+// prune forgets the parentheses before the offset and the ones that are not closed,
+// it is used when text is added after s as the parentheses could be closed in it.
+func (p *parentheses) prune(offset int) {
+	for i, end := range p.end {
+		if i < offset || end < 0 {
+			delete(p.end, i)
+		}
+	}
+}
+
+// skipQuoted returns the position right after the quoted text that starts at i,
+// if line is true a double quoted text also ends at the end of the line, a text block never does.
+func skipQuoted(s string, i int, line bool) int {
+	if strings.HasPrefix(s[i:], textBlockQuote) {
+		start := i + len(textBlockQuote)
+		if end := strings.Index(s[start:], textBlockQuote); end >= 0 {
+			return start + end + len(textBlockQuote)
+		}
+		return len(s)
+	}
+	quote := s[i]
+	for j := i + 1; j < len(s); j++ {
+		switch {
+		case s[j] == '\\' && quote != '`':
+			j++
+		case s[j] == quote:
+			return j + 1
+		case s[j] == '\n' && line && quote == '"':
+			return j + 1
+		}
+	}
+	return len(s)
+}
+
+// atLineStart tells if there is only white space between the start of the line and i.
+func atLineStart(s string, i int) bool {
+	for i > 0 {
+		r, size := utf8.DecodeLastRuneInString(s[:i])
+		if r == '\n' {
+			return true
+		}
+		if !unicode.IsSpace(r) {
+			return false
+		}
+		i -= size
+	}
+	return true
+}
+
+// isWordRune tells if r can be part of a word, an e-mail address or an annotation name.
+func isWordRune(r rune) bool {
+	return r == '_' || r == '.' || r == '-' || r == '+' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
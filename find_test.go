@@ -0,0 +1,244 @@
+package annotation
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want []Match
+	}{
+		{
+			name: "Should find the parameters written after white space",
+			s:    "// @Get (\"/x\")",
+			want: []Match{
+				{
+					Annotation: Annotation{
+						Name: "Get",
+						parameters: map[string]attrValue{
+							DefaultParameter: {Str: pointerString("/x")},
+						},
+						order: []string{DefaultParameter},
+					},
+					Offset: 3,
+					End:    14,
+				},
+			},
+		},
+		{
+			name: "Should find annotations in text",
+			s:    "Get returns the user @Get(\"/users\") for the id.",
+			want: []Match{
+				{
+					Annotation: Annotation{
+						Name: "Get",
+						parameters: map[string]attrValue{
+							DefaultParameter: {Str: pointerString("/users")},
+						},
+						order: []string{DefaultParameter},
+					},
+					Offset: 21,
+					End:    35,
+				},
+			},
+		},
+		{
+			name: "Should find marker annotations at the start of a line",
+			s:    "Some text @NotAMarker\n  @Deprecated\n",
+			want: []Match{
+				{
+					Annotation: Annotation{
+						Name:       "Deprecated",
+						parameters: map[string]attrValue{},
+						marker:     true,
+					},
+					Offset: 24,
+					End:    35,
+				},
+			},
+		},
+		{
+			name: "Should skip e-mail addresses",
+			s:    "Contact a@b.com or first.last@example.com(for help)",
+			want: nil,
+		},
+		{
+			name: "Should skip annotations in quoted text",
+			s:    "Write \"@Get()\" or `@Get()` to add the route",
+			want: nil,
+		},
+		{
+			name: "Should skip text that can not be parsed",
+			s:    "Call @Get( and @Post(path=)",
+			want: nil,
+		},
+		{
+			name: "Should skip the text blocks of annotations",
+			s:    "@Doc(\"\"\"\n  a) \"b\"\n  @Get\n\"\"\")",
+			want: []Match{
+				{
+					Annotation: Annotation{
+						Name: "Doc",
+						parameters: map[string]attrValue{
+							DefaultParameter: {Str: pointerString("a) \"b\"\n@Get")},
+						},
+						order: []string{DefaultParameter},
+					},
+					Offset: 0,
+					End:    29,
+				},
+			},
+		},
+		{
+			name: "Should not find nested annotations separately",
+			s:    "@Endpoint(auth=@Auth(value=\")\"))",
+			want: []Match{
+				{
+					Annotation: Annotation{
+						Name: "Endpoint",
+						parameters: map[string]attrValue{
+							"auth": {
+								A: &Annotation{
+									Name: "Auth",
+									parameters: map[string]attrValue{
+										DefaultParameter: {Str: pointerString(")")},
+									},
+									order: []string{DefaultParameter},
+								},
+							},
+						},
+						order: []string{"auth"},
+					},
+					Offset: 0,
+					End:    32,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Find(tt.s)
+			for i := range got {
+				got[i].Annotation = withoutRanges(got[i].Annotation)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Find() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFind_hostile(t *testing.T) {
+	n := 100000
+	tests := []struct {
+		name string
+		s    string
+	}{
+		{
+			name: "Should find nothing in annotations that are never closed",
+			s:    strings.Repeat("@A(", n),
+		},
+		{
+			name: "Should find nothing in markers that are not at the start of a line",
+			s:    "text" + strings.Repeat(" @A", n),
+		},
+		{
+			name: "Should find nothing in nested annotations that can not be parsed",
+			s:    strings.Repeat("@A(", n) + "x=" + strings.Repeat(")", n),
+		},
+		{
+			name: "Should find nothing in quotes that are never closed",
+			s:    strings.Repeat("@A(\"", n),
+		},
+		{
+			name: "Should find nothing in a large map with a duplicate key",
+			s:    "@A(m={k: 1, " + mapEntries(n) + "k: 2})",
+		},
+		{
+			name: "Should find nothing in invalid UTF-8",
+			s:    strings.Repeat("@\xff(\xc3", n),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Find(tt.s); len(got) != 0 {
+				t.Errorf("Find() = %d matches, want none", len(got))
+			}
+			if _, err := ParseAll(tt.s); err == nil {
+				t.Errorf("ParseAll() error = nil")
+			}
+			if _, err := Parse(tt.s); err == nil {
+				t.Errorf("Parse() error = nil")
+			}
+		})
+	}
+}
+
+// mapEntries returns n map entries with different keys e.x `k0: 0, k1: 1, `.
+func mapEntries(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		b.WriteString("k" + strconv.Itoa(i) + ": " + strconv.Itoa(i) + ", ")
+	}
+	return b.String()
+}
+
+func TestFindAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		wantLen  int
+		wantErrs []string
+	}{
+		{
+			name:    "Should find the annotations without errors",
+			s:       "Contact a@b.com.\n@Get(\"/users\")\n@Deprecated",
+			wantLen: 2,
+		},
+		{
+			name:    "Should report the annotations that can not be parsed",
+			s:       "Get returns a user @Get(path=) and @Auth(roles=[\"admin\"\n@Deprecated",
+			wantLen: 1,
+			wantErrs: []string{
+				"1:30: unexpected `)`, expected a value",
+				"1:56: unexpected end of input, expected `,` or `]`",
+			},
+		},
+		{
+			name:     "Should report a marker annotation at the start of a line that can not be parsed",
+			s:        "@Get(path=\"/users\")\n  @Cache(ttl==1)",
+			wantLen:  1,
+			wantErrs: []string{"2:14: unexpected `=`, expected a value"},
+		},
+		{
+			name:    "Should not report the text that is not an annotation",
+			s:       "Text @Note and \"@Get(\" or a@b.com(x=)",
+			wantLen: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindAll(tt.s, Position{})
+			if len(got) != tt.wantLen {
+				t.Errorf("FindAll() = %d matches, want %d", len(got), tt.wantLen)
+			}
+			var gotErrs []string
+			if list, ok := err.(ErrorList); ok {
+				for _, e := range list {
+					gotErrs = append(gotErrs, e.Error())
+				}
+			}
+			if !reflect.DeepEqual(gotErrs, tt.wantErrs) {
+				t.Errorf("FindAll() errors = %v, want %v", gotErrs, tt.wantErrs)
+			}
+			if matches := Find(tt.s); len(matches) != len(got) {
+				t.Errorf("Find() = %d matches, want the %d of FindAll()", len(matches), len(got))
+			}
+		})
+	}
+}
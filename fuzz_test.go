@@ -0,0 +1,74 @@
+package annotation
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// fuzzSeeds adds the corpus and some hostile inputs to the seed corpus of f.
+func fuzzSeeds(f *testing.F) {
+	for _, c := range corpus {
+		f.Add(c.s)
+	}
+	f.Add("@A(\xff)")
+	f.Add("@\xc3(a=\"\xff\")")
+	f.Add("@A(@A(@A(")
+	f.Add("text @A\n @B(x=[{a: @C}])")
+	f.Add("@A(s=\"\\xff\", r=`@B(`)")
+}
+
+func FuzzParse(f *testing.F) {
+	fuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, s string) {
+		a, err := Parse(s)
+		var perr *ParseError
+		if err != nil && !errors.As(err, &perr) {
+			t.Fatalf("Parse() error = %T, want a *ParseError", err)
+		}
+		if err == nil && a == nil {
+			t.Fatalf("Parse() = nil, nil")
+		}
+	})
+}
+
+func FuzzParseAll(f *testing.F) {
+	fuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, s string) {
+		_, err := ParseAll(s)
+		var list ErrorList
+		if err != nil && !errors.As(err, &list) {
+			t.Fatalf("ParseAll() error = %T, want an ErrorList", err)
+		}
+	})
+}
+
+func FuzzFind(f *testing.F) {
+	fuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, s string) {
+		end := 0
+		for _, m := range Find(s) {
+			if m.Offset < end || m.End <= m.Offset || m.End > len(s) {
+				t.Fatalf("Find() match from %d to %d after %d in %d bytes", m.Offset, m.End, end, len(s))
+			}
+			end = m.End
+		}
+	})
+}
+
+func FuzzReader(f *testing.F) {
+	fuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, s string) {
+		r := NewReader(iotest.OneByteReader(strings.NewReader(s)))
+		for i := 0; ; i++ {
+			if i > len(s) {
+				t.Fatalf("Reader.Read() does not stop")
+			}
+			if _, err := r.Read(); err == io.EOF {
+				return
+			}
+		}
+	})
+}
@@ -1,5 +1,5 @@
 module github.com/go-services/annotation
 
-go 1.12
+go 1.18
 
 require github.com/alecthomas/participle v0.7.1
@@ -0,0 +1,308 @@
+package annotation
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind is the kind of a token of the annotation syntax.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenInt
+	tokenFloat
+	tokenDuration
+	tokenString
+
+	// tokenChar is any other single character e.x `@`, `(` or `=`
+	tokenChar
+)
+
+// token is a token of the annotation syntax, start and end are byte offsets in the parsed text.
+type token struct {
+	kind  tokenKind
+	start int
+	end   int
+
+	// str is the unquoted value of a string token.
+	str string
+}
+
+// next scans the next token, the white space and the Go comments before it are skipped.
+func (p *parser) next() error {
+	p.prev = p.tok.end
+	i, err := p.skip(p.tok.end)
+	if err != nil {
+		return err
+	}
+	p.tok = token{start: i, end: i}
+	if i == len(p.s) {
+		p.tok.kind = tokenEOF
+		return nil
+	}
+	c := p.s[i]
+	switch {
+	case c == '"' || c == '\'' || c == '`':
+		return p.scanString(i)
+	case isDigit(c) || c == '.' && i+1 < len(p.s) && isDigit(p.s[i+1]):
+		p.scanNumber(i)
+		return nil
+	}
+	r, size := utf8.DecodeRuneInString(p.s[i:])
+	if isIdentStart(r) {
+		p.tok.kind = tokenIdent
+		p.tok.end = identEnd(p.s, i)
+		return nil
+	}
+	p.tok.kind = tokenChar
+	p.tok.end = i + size
+	return nil
+}
+
+// skip returns the position of the first character after i that is not white space or in a comment.
+func (p *parser) skip(i int) (int, error) {
+	for i < len(p.s) {
+		switch {
+		case isSpace(p.s[i]):
+			i++
+		case strings.HasPrefix(p.s[i:], "//"):
+			end := strings.IndexByte(p.s[i:], '\n')
+			if end < 0 {
+				return len(p.s), nil
+			}
+			i += end + 1
+		case strings.HasPrefix(p.s[i:], "/*"):
+			end := strings.Index(p.s[i+2:], "*/")
+			if end < 0 {
+				return 0, p.src.newError(len(p.s), "comment not terminated")
+			}
+			i += end + 4
+		default:
+			return i, nil
+		}
+	}
+	return i, nil
+}
+
+// scanString scans a "double quoted", 'single quoted' or `raw` string or a """text block""" that starts at i,
+// the double and single quoted strings can not span several lines.
+func (p *parser) scanString(i int) error {
+	if strings.HasPrefix(p.s[i:], textBlockQuote) {
+		return p.scanTextBlock(i)
+	}
+	quote := p.s[i]
+	escaped := false
+	j := i + 1
+	for ; ; j++ {
+		if j >= len(p.s) || p.s[j] == '\n' && quote != '`' {
+			return p.src.newError(j, "literal not terminated")
+		}
+		if p.s[j] == quote {
+			break
+		}
+		if p.s[j] == '\\' && quote != '`' {
+			escaped = true
+			j++
+		}
+	}
+	p.tok.kind = tokenString
+	p.tok.end = j + 1
+	p.tok.str = p.s[i+1 : j]
+	if p.maxStringLength > 0 && len(p.tok.str) > p.maxStringLength {
+		return p.src.limitError(i, LimitStringLength, p.maxStringLength, "string is longer than %d bytes")
+	}
+	if !escaped {
+		return nil
+	}
+	s := p.s[i:p.tok.end]
+	if quote == '\'' {
+		s = doubleQuote(p.tok.str)
+	}
+	str, err := strconv.Unquote(s)
+	if err != nil {
+		return p.src.newError(i, "invalid string `"+p.s[i:p.tok.end]+"`")
+	}
+	p.tok.str = str
+	return nil
+}
+
+// textBlockQuote starts and ends a text block.
+const textBlockQuote = `"""`
+
+// scanTextBlock scans a """text block""" that starts at i, the text of a text block is not unescaped.
+func (p *parser) scanTextBlock(i int) error {
+	start := i + len(textBlockQuote)
+	end := strings.Index(p.s[start:], textBlockQuote)
+	if end < 0 {
+		return p.src.newError(len(p.s), "text block not terminated")
+	}
+	end += start
+	p.tok.kind = tokenString
+	p.tok.end = end + len(textBlockQuote)
+	if p.maxStringLength > 0 && end-start > p.maxStringLength {
+		return p.src.limitError(i, LimitStringLength, p.maxStringLength, "string is longer than %d bytes")
+	}
+	p.tok.str = textBlock(p.s[start:end])
+	return nil
+}
+
+// textBlock returns the text of a text block that spans several comment lines e.x
+//
+//	// @Query(sql="""
+//	//     SELECT *
+//	//     FROM users
+//	// """)
+//
+// is `SELECT *\nFROM users`. The `//` at the start of the lines after the first one are removed
+// if every one of these lines that is not blank starts with it, a text like `//cdn.example.com` is kept otherwise.
+// Then the indentation that these lines have in common is removed, the blank lines excepted.
+// The first and the last line are dropped if they are blank and the white space at the end of the lines is removed.
+func textBlock(s string) string {
+	lines := strings.Split(s, "\n")
+	comment := true
+	for _, line := range lines[1:] {
+		if trimmed := strings.TrimLeft(line, " \t\r"); trimmed != "" && !strings.HasPrefix(trimmed, "//") {
+			comment = false
+			break
+		}
+	}
+	indent := -1
+	for i, line := range lines {
+		if i > 0 && comment {
+			if trimmed := strings.TrimLeft(line, " \t"); strings.HasPrefix(trimmed, "//") {
+				line = trimmed[2:]
+			}
+		}
+		line = strings.TrimRight(line, " \t\r")
+		if n := len(line) - len(strings.TrimLeft(line, " \t")); i > 0 && line != "" && (indent < 0 || n < indent) {
+			indent = n
+		}
+		lines[i] = line
+	}
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = lines[i][indent:]
+		}
+	}
+	if len(lines) > 1 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	if len(lines) > 1 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// doubleQuote converts the content of a single quoted string to a double quoted string.
+func doubleQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == '\'':
+			b.WriteByte('\'')
+			i++
+		case s[i] == '\\' && i+1 < len(s):
+			b.WriteString(s[i : i+2])
+			i++
+		case s[i] == '"':
+			b.WriteString(`\"`)
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// scanNumber scans the Go numeric literal that starts at i,
+// a unit written right after the number e.x `1h30m` makes it a duration.
+// The literal is only checked when it is converted to a value.
+func (p *parser) scanNumber(i int) {
+	s := p.s
+	p.tok.kind = tokenInt
+	base := byte(10)
+	if s[i] == '0' && i+1 < len(s) {
+		switch lower(s[i+1]) {
+		case 'x':
+			base = 16
+		case 'o':
+			base = 8
+		case 'b':
+			base = 2
+		}
+		if base != 10 {
+			i += 2
+		}
+	}
+	i = digitsEnd(s, i, base)
+	if (base == 10 || base == 16) && i < len(s) && s[i] == '.' {
+		p.tok.kind = tokenFloat
+		i = digitsEnd(s, i+1, base)
+	}
+	if i < len(s) && (base == 10 && lower(s[i]) == 'e' || base == 16 && lower(s[i]) == 'p') {
+		j := i + 1
+		if j < len(s) && (s[j] == '-' || s[j] == '+') {
+			j++
+		}
+		if j < len(s) && isDigit(s[j]) {
+			p.tok.kind = tokenFloat
+			i = digitsEnd(s, j, 10)
+		}
+	}
+	if i < len(s) {
+		if r, _ := utf8.DecodeRuneInString(s[i:]); isIdentStart(r) {
+			p.tok.kind = tokenDuration
+			i = unitEnd(s, i)
+		}
+	}
+	p.tok.end = i
+}
+
+// digitsEnd returns the position of the first character after i that is not a digit of the base or `_`.
+func digitsEnd(s string, i int, base byte) int {
+	for i < len(s) && (isDigit(s[i]) || s[i] == '_' || base == 16 && 'a' <= lower(s[i]) && lower(s[i]) <= 'f') {
+		i++
+	}
+	return i
+}
+
+// unitEnd returns the end of the unit of a duration that starts at i e.x `h30m` in `1h30m`.
+func unitEnd(s string, i int) int {
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r != '.' && !isIdentStart(r) && !unicode.IsDigit(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+// identEnd returns the end of the identifier that starts at i.
+func identEnd(s string, i int) int {
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !isIdentStart(r) && !unicode.IsDigit(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func lower(c byte) byte {
+	return c | ('x' - 'X')
+}
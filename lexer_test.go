@@ -0,0 +1,108 @@
+package annotation
+
+import (
+	"testing"
+)
+
+func Test_textBlock(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "Should return the text of a single line",
+			s:    "SELECT * FROM users",
+			want: "SELECT * FROM users",
+		},
+		{
+			name: "Should remove the common indentation and the blank first and last lines",
+			s:    "\n    SELECT *\n      FROM users\n    WHERE id = ?\n  ",
+			want: "SELECT *\n  FROM users\nWHERE id = ?",
+		},
+		{
+			name: "Should remove the comment prefixes",
+			s:    "\n//   SELECT *\n//   FROM users\n// ",
+			want: "SELECT *\nFROM users",
+		},
+		{
+			name: "Should remove the comment prefixes after the indentation",
+			s:    "\n\t//\tSELECT *\n\t//\t\tFROM users\n\t//",
+			want: "SELECT *\n\tFROM users",
+		},
+		{
+			name: "Should keep the slashes if a line does not start with them",
+			s:    "\n  //cdn.example.com/a\n  b\n",
+			want: "//cdn.example.com/a\nb",
+		},
+		{
+			name: "Should remove the comment prefixes of the lines that are not blank",
+			s:    "\n// first\n\n// second\n",
+			want: "first\n\nsecond",
+		},
+		{
+			name: "Should keep the blank lines in the text",
+			s:    "\n  first\n\n  second   \n",
+			want: "first\n\nsecond",
+		},
+		{
+			name: "Should keep the text of the first line",
+			s:    "Returns the user\n  with the given id\n  ",
+			want: "Returns the user\nwith the given id",
+		},
+		{
+			name: "Should return an empty text",
+			s:    "\n  \n",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := textBlock(tt.s); got != tt.want {
+				t.Errorf("textBlock() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_textBlock(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "Should parse a text block in a comment",
+			s:    "@Query(sql=\"\"\"\n//     SELECT \"name\"\n//     FROM users\n//     WHERE id = @id\n// \"\"\", cache=true)",
+			want: "SELECT \"name\"\nFROM users\nWHERE id = @id",
+		},
+		{
+			name: "Should not unescape a text block",
+			s:    `@Doc("""a\nb""")`,
+			want: `a\nb`,
+		},
+		{
+			name:    "Should reject a text block that is not terminated",
+			s:       "@Doc(\"\"\"\n  text\n)",
+			wantErr: "3:2: text block not terminated",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := Parse(tt.s)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tt.wantErr {
+				t.Fatalf("Parse() error = %v, want %v", gotErr, tt.wantErr)
+			}
+			if err == nil {
+				if got := a.Get(a.Keys()[0]).String(); got != tt.want {
+					t.Errorf("Parse() text = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
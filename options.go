@@ -0,0 +1,106 @@
+package annotation
+
+// Option changes the way a Parser parses annotations.
+type Option func(p *Parser)
+
+// Strict only accepts annotations with parentheses and named parameters e.x `@Path(path="/users")`,
+// marker annotations e.x `@Deprecated` and positional values e.x `@Path("/users")` are errors.
+func Strict() Option {
+	return func(p *Parser) {
+		p.strict = true
+	}
+}
+
+// AllowTypes only accepts values of the given types e.x `AllowTypes(STRING, INT, BOOL)`,
+// the types are the ones returned by Value.Type, a value of any other type is an error.
+// List, map and nested annotation values are checked together with the values in them.
+func AllowTypes(types ...ValueType) Option {
+	return func(p *Parser) {
+		p.types = map[ValueType]bool{}
+		for _, t := range types {
+			p.types[t] = true
+		}
+	}
+}
+
+// DuplicatePolicy tells what a Parser does with a parameter that is set more than once.
+type DuplicatePolicy int
+
+const (
+	// DuplicateReject makes a parameter that is set more than once an error, it is the default.
+	DuplicateReject DuplicatePolicy = iota
+
+	// DuplicateFirst keeps the first value of the parameter.
+	DuplicateFirst
+
+	// DuplicateLast keeps the last value of the parameter.
+	DuplicateLast
+
+	// DuplicateList keeps every value of the parameter in a list e.x `@Tag(name="a", name="b")` has `name=["a", "b"]`.
+	DuplicateList
+)
+
+// Duplicates sets what happens when a parameter is set more than once e.x `@Route(path="/a", path="/b")`,
+// without the option it is an error that has the positions of both parameters.
+func Duplicates(policy DuplicatePolicy) Option {
+	return func(p *Parser) {
+		p.duplicates = policy
+	}
+}
+
+// Expand replaces the `${NAME}` and `${NAME:-default}` variable references in the string values with the value
+// that lookup returns for the name e.x `Expand(os.LookupEnv)`, the default is used if the variable is not set or empty.
+// A variable that is not set and has no default is an error, `$${` is written as `${` and is not a reference.
+func Expand(lookup func(name string) (string, bool)) Option {
+	return func(p *Parser) {
+		p.lookup = lookup
+	}
+}
+
+// MapLookup returns a lookup for Expand that looks the variables up in m.
+func MapLookup(m map[string]string) func(name string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := m[name]
+		return v, ok
+	}
+}
+
+// MaxLength limits the length in bytes of the string given to Parse and ParseAll
+// and of every annotation found by Find or read by a Reader, a longer one is an error.
+func MaxLength(n int) Option {
+	return func(p *Parser) {
+		p.maxLength = n
+	}
+}
+
+// MaxParameters limits the number of parameters of every annotation, the nested annotations as well.
+func MaxParameters(n int) Option {
+	return func(p *Parser) {
+		p.maxParameters = n
+	}
+}
+
+// MaxDepth limits how deep lists, maps and annotations can be nested e.x `[[1]]` has a depth of 2.
+// The depth is limited to 10000 if the option is not used or n is not positive
+// and it is never more than 100000 so a hostile annotation can not exhaust the stack.
+func MaxDepth(n int) Option {
+	return func(p *Parser) {
+		p.maxDepth = n
+	}
+}
+
+// MaxStringLength limits the length in bytes of the quoted strings e.x the string values and the quoted map keys.
+func MaxStringLength(n int) Option {
+	return func(p *Parser) {
+		p.maxStringLength = n
+	}
+}
+
+// MaxAnnotations limits the number of annotations that ParseAll parses from a string or a Reader reads,
+// more annotations are an error. FindAll reports an annotation found after that many annotations as an error as well,
+// Find and FindAt that do not return errors stop there.
+func MaxAnnotations(n int) Option {
+	return func(p *Parser) {
+		p.maxAnnotations = n
+	}
+}
@@ -0,0 +1,362 @@
+package annotation
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParser_Parse_options(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		s       string
+		wantErr string
+	}{
+		{
+			name: "Should parse every form without options",
+			s:    `@Route(path="/users", methods=[GET, POST], timeout=5s, auth=@Auth)`,
+		},
+		{
+			name:    "Should parse named parameters in strict mode",
+			options: []Option{Strict()},
+			s:       `@Route(path="/users", auth=@Auth())`,
+		},
+		{
+			name:    "Should reject a marker annotation in strict mode",
+			options: []Option{Strict()},
+			s:       `@Deprecated`,
+			wantErr: "1:12: marker annotation `@Deprecated` must have parentheses, expected `(`",
+		},
+		{
+			name:    "Should reject a nested marker annotation in strict mode",
+			options: []Option{Strict()},
+			s:       `@Route(path="/users", auth=@Auth)`,
+			wantErr: "1:33: marker annotation `@Auth` must have parentheses, expected `(`",
+		},
+		{
+			name:    "Should reject a positional value in strict mode",
+			options: []Option{Strict()},
+			s:       `@Path("/users")`,
+			wantErr: "1:7: parameter of `@Path()` Annotation must have a name",
+		},
+		{
+			name:    "Should parse the allowed types",
+			options: []Option{AllowTypes(STRING, INT, LIST)},
+			s:       `@Route(path="/users", ports=[80, 443])`,
+		},
+		{
+			name:    "Should reject a type that is not allowed",
+			options: []Option{AllowTypes(STRING, INT, FLOAT, BOOL)},
+			s:       `@Cache(ttl=5m)`,
+			wantErr: "1:12: duration values are not allowed",
+		},
+		{
+			name:    "Should reject a type that is not allowed in a list",
+			options: []Option{AllowTypes(STRING, LIST)},
+			s:       `@Route(methods=["GET", POST])`,
+			wantErr: "1:24: ident values are not allowed",
+		},
+		{
+			name:    "Should parse an annotation that is not longer than the limit",
+			options: []Option{MaxLength(15)},
+			s:       `@Path("/users")`,
+		},
+		{
+			name:    "Should reject an annotation that is longer than the limit",
+			options: []Option{MaxLength(10)},
+			s:       `@Path("/users")`,
+			wantErr: "1:1: annotation is longer than 10 bytes",
+		},
+		{
+			name:    "Should parse an annotation that does not exceed the limits",
+			options: []Option{MaxParameters(2), MaxDepth(2), MaxStringLength(6)},
+			s:       `@Route(path="/users", auth=@Auth(roles=["admin"]))`,
+		},
+		{
+			name:    "Should reject an annotation with too many parameters",
+			options: []Option{MaxParameters(2)},
+			s:       `@Route(path="/users", method=GET, auth=@Auth)`,
+			wantErr: "1:35: annotation has more than 2 parameters",
+		},
+		{
+			name:    "Should reject a nested annotation with too many parameters",
+			options: []Option{MaxParameters(1)},
+			s:       `@Route(auth=@Auth(user="admin", roles=[]))`,
+			wantErr: "1:33: annotation has more than 1 parameters",
+		},
+		{
+			name:    "Should reject values that are nested too deep",
+			options: []Option{MaxDepth(2)},
+			s:       `@Route(auth=@Auth(roles=[["admin"]]))`,
+			wantErr: "1:26: value is nested deeper than 2 levels",
+		},
+		{
+			name:    "Should reject a string that is longer than the limit",
+			options: []Option{MaxStringLength(5)},
+			s:       `@Route(path="/users")`,
+			wantErr: "1:13: string is longer than 5 bytes",
+		},
+		{
+			name:    "Should reject a map key that is longer than the limit",
+			options: []Option{MaxStringLength(5)},
+			s:       `@Route(headers={"Accept": "*/*"})`,
+			wantErr: "1:17: string is longer than 5 bytes",
+		},
+		{
+			name:    "Should reject a parameter that is set twice",
+			s:       `@Route(path="/a", path="/b")`,
+			wantErr: "1:19: duplicate parameter `path` in `@Route()` Annotation, first set at 1:8",
+		},
+		{
+			name:    "Should reject a parameter that is set twice in a nested annotation",
+			s:       "@Route(auth=@Auth(\n\trole=\"a\",\n\trole=\"b\"))",
+			wantErr: "3:2: duplicate parameter `role` in `@Auth()` Annotation, first set at 2:2",
+		},
+		{
+			name:    "Should reject a positional value mixed with the value parameter",
+			s:       `@Route("/a", value="/b")`,
+			wantErr: "1:8: positional value in `@Route()` Annotation must be the only parameter",
+		},
+		{
+			name:    "Should reject the value parameter mixed with a positional value",
+			options: []Option{Duplicates(DuplicateList)},
+			s:       `@Route(value="/a", "/b")`,
+			wantErr: "1:20: positional value in `@Route()` Annotation must be the only parameter",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser(tt.options...).Parse(tt.s)
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.wantErr {
+				t.Errorf("Parser.Parse() error = %v, want %v", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParser_concurrent(t *testing.T) {
+	p := NewParser()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				a, err := p.Parse(`@Route(path="/users", methods=[GET, POST], cache={ttl: 5m})`)
+				if err != nil {
+					t.Errorf("Parser.Parse() error = %v", err)
+					return
+				}
+				if got := a.Get("path").String(); got != "/users" {
+					t.Errorf("Parser.Parse() path = %v, want /users", got)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestParser_limits(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		s       string
+		want    *LimitError
+	}{
+		{
+			name:    "Should report the length limit",
+			options: []Option{MaxLength(10)},
+			s:       `@Path("/users")`,
+			want:    &LimitError{Limit: LimitLength, Max: 10},
+		},
+		{
+			name:    "Should report the parameters limit",
+			options: []Option{MaxParameters(1)},
+			s:       `@Route(path="/users", method=GET)`,
+			want:    &LimitError{Limit: LimitParameters, Max: 1},
+		},
+		{
+			name: "Should report the default depth limit",
+			s:    "@Values(" + strings.Repeat("[", 10001) + strings.Repeat("]", 10001) + ")",
+			want: &LimitError{Limit: LimitDepth, Max: 10000},
+		},
+		{
+			name:    "Should report the default depth limit if the limit is not positive",
+			options: []Option{MaxDepth(0)},
+			s:       "@A(x=" + strings.Repeat("[", 200000),
+			want:    &LimitError{Limit: LimitDepth, Max: 10000},
+		},
+		{
+			name:    "Should report the highest depth limit if the limit is higher",
+			options: []Option{MaxDepth(1 << 30)},
+			s:       "@A(x=" + strings.Repeat("[", 200000),
+			want:    &LimitError{Limit: LimitDepth, Max: 100000},
+		},
+		{
+			name:    "Should report the string length limit",
+			options: []Option{MaxStringLength(3)},
+			s:       "@Path(`/users`)",
+			want:    &LimitError{Limit: LimitStringLength, Max: 3},
+		},
+		{
+			name:    "Should not report a limit for other errors",
+			options: []Option{MaxLength(100)},
+			s:       `@Path(`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser(tt.options...).Parse(tt.s)
+			if err == nil {
+				t.Fatalf("Parser.Parse() error = nil")
+			}
+			var got *LimitError
+			errors.As(err, &got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parser.Parse() limit error = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_ParseAll_limits(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		s       string
+		wantLen int
+		wantErr string
+	}{
+		{
+			name:    "Should parse the annotations up to the limit",
+			options: []Option{MaxAnnotations(2)},
+			s:       "@Get\n@Deprecated",
+			wantLen: 2,
+		},
+		{
+			name:    "Should stop after the limit of annotations",
+			options: []Option{MaxAnnotations(2)},
+			s:       "@Get\n@Deprecated\n@Internal\n@Cache",
+			wantLen: 2,
+			wantErr: "3:1: there are more than 2 annotations",
+		},
+		{
+			name:    "Should reject a string that is longer than the limit",
+			options: []Option{MaxLength(10)},
+			s:       "@Get\n@Deprecated",
+			wantErr: "1:1: annotations are longer than 10 bytes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewParser(tt.options...).ParseAll(tt.s)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if len(got) != tt.wantLen || gotErr != tt.wantErr {
+				t.Errorf("Parser.ParseAll() = %d annotations, %v, want %d, %v", len(got), gotErr, tt.wantLen, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParser_Find_limits(t *testing.T) {
+	got := NewParser(MaxAnnotations(1)).Find("@Get(\"/users\")\n@Deprecated")
+	if len(got) != 1 || got[0].Annotation.Name != "Get" {
+		t.Errorf("Parser.Find() = %v, want only @Get", got)
+	}
+	matches, err := NewParser(MaxAnnotations(2)).FindAll("Get a user.\n@Get(\"/users\")\n@Auth\n@Deprecated", Position{})
+	var limit *LimitError
+	if len(matches) != 2 || !errors.As(err, &limit) || limit.Limit != LimitAnnotations {
+		t.Errorf("Parser.FindAll() = %d matches, %v, want 2 matches and a LimitError", len(matches), err)
+	}
+	if want := "4:1: there are more than 2 annotations"; err == nil || err.Error() != want {
+		t.Errorf("Parser.FindAll() error = %v, want %v", err, want)
+	}
+}
+
+func TestParser_NewReader_limits(t *testing.T) {
+	r := NewParser(MaxAnnotations(2)).NewReader(strings.NewReader("@A @B @C @D"))
+	var names []string
+	var err error
+	for {
+		var a Annotation
+		if a, err = r.Read(); err != nil {
+			break
+		}
+		names = append(names, a.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"A", "B"}) {
+		t.Errorf("Reader.Read() = %v, want [A B]", names)
+	}
+	if want := "1:7: there are more than 2 annotations"; err.Error() != want {
+		t.Errorf("Reader.Read() error = %v, want %v", err, want)
+	}
+	if _, err := r.Read(); err != io.EOF {
+		t.Errorf("Reader.Read() error = %v, want io.EOF after the limit", err)
+	}
+}
+
+func TestParser_Parse_duplicates(t *testing.T) {
+	s := `@Tag(name="a", other=1, name="b", name="c")`
+	tests := []struct {
+		name   string
+		policy DuplicatePolicy
+		want   interface{}
+	}{
+		{
+			name:   "Should keep the first value",
+			policy: DuplicateFirst,
+			want:   "a",
+		},
+		{
+			name:   "Should keep the last value",
+			policy: DuplicateLast,
+			want:   "c",
+		},
+		{
+			name:   "Should collect the values in a list",
+			policy: DuplicateList,
+			want:   []interface{}{"a", "b", "c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewParser(Duplicates(tt.policy)).Parse(s)
+			if err != nil {
+				t.Fatalf("Parser.Parse() error = %v", err)
+			}
+			v := a.Get("name")
+			var got interface{} = v.String()
+			if list := v.List(); list != nil {
+				var values []interface{}
+				for _, e := range list {
+					values = append(values, e.String())
+				}
+				got = values
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parser.Parse() name = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_Parse_duplicateError(t *testing.T) {
+	_, err := Parse(`@Route(path="/a", method=GET, path="/b")`)
+	var got *DuplicateError
+	want := &DuplicateError{Name: "path", First: Position{Offset: 7, Line: 1, Column: 8}}
+	if !errors.As(err, &got) || !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() error = %v, want %v", got, want)
+	}
+}
@@ -2,123 +2,860 @@ package annotation
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
 	"strings"
 
 	"strconv"
-
-	"github.com/alecthomas/participle"
+	"time"
 )
 
+// attrValue holds a parsed parameter value.
 type attrValue struct {
-	Str    *string  `parser:"@String"`
-	RStr   *string  `parser:"| @RawString"`
-	I      *int     `parser:"| @Int"`
-	F      *float64 `parser:"| @Float"`
-	VTrue  bool     `parser:"| @'true'"`
-	VFalse bool     `parser:"| @'false'"`
+	Str    *string
+	I      *int64
+	B      *big.Int
+	F      *float64
+	BF     *big.Float
+	D      *time.Duration
+	VTrue  bool
+	VFalse bool
+	Null   bool
+	Ident  *string
+	L      []attrValue
+	M      []mapEntry
+	A      *Annotation
+
+	// rng is the range of the value in the parsed string
+	rng Range
 }
 
-// value is a helper struct for the parser to parse `parameter="value"`  pairs.
-type value struct {
-	Key   string     `parser:"@Ident'='"`
-	Value *attrValue `parser:"@@"`
+// mapEntry holds a single key value pair of a map value.
+type mapEntry struct {
+	Key   string
+	Value attrValue
 }
 
-// ann is the struct that is used to parse parameters in comments.
-type ann struct {
-	Name   string   `parser:"'@' @Ident'('"`
-	Values []*value `parser:"[@@{','@@}]')'"`
+// defaultParser is the Parser used by the package level functions.
+var defaultParser = NewParser()
+
+const (
+	// defaultMaxDepth is the depth limit if MaxDepth is not used.
+	defaultMaxDepth = 10000
+
+	// hardMaxDepth is the highest depth limit that MaxDepth can set.
+	hardMaxDepth = 100000
+)
+
+// Parser parses annotations with a set of options,
+// it is safe to use the same Parser from several goroutines.
+//
+// A Parser never panics and the time it takes is linear in the length of the input whatever the input is,
+// invalid UTF-8 included. The limits can be used to bound it further for untrusted input.
+type Parser struct {
+	strict     bool
+	types      map[ValueType]bool
+	duplicates DuplicatePolicy
+	lookup     func(name string) (string, bool)
+
+	maxLength       int
+	maxParameters   int
+	maxDepth        int
+	maxStringLength int
+	maxAnnotations  int
+}
+
+// NewParser creates a new Parser with the given options,
+// without options it parses the same way as the package level functions.
+func NewParser(options ...Option) *Parser {
+	p := &Parser{}
+	for _, o := range options {
+		o(p)
+	}
+	return p
 }
 
 // Parse finds an ann in a string.
 func Parse(s string) (*Annotation, error) {
-	s = prepareString(s)
-	if !strings.HasPrefix(s, "@") {
-		return nil, errors.New("annotation not found in string")
+	return defaultParser.Parse(s)
+}
+
+// Parse parses the annotation in a string with the options of the Parser e.x its limits,
+// it is the same as the package level Parse otherwise.
+func (p *Parser) Parse(s string) (*Annotation, error) {
+	return p.parseAt(s, Position{})
+}
+
+// parseAt parses an annotation, the positions are reported as if the string started at base.
+func (p *Parser) parseAt(s string, base Position) (*Annotation, error) {
+	return p.parseIn(newSource(s, base), 0, len(s))
+}
+
+// parseIn parses the annotation in the text of src between the byte offsets start and end.
+func (p *Parser) parseIn(src *source, start, end int) (*Annotation, error) {
+	s := src.text[start:end]
+	src = src.from(start)
+	if p.maxLength > 0 && len(s) > p.maxLength {
+		return nil, src.limitError(0, LimitLength, p.maxLength, "annotation is longer than %d bytes")
+	}
+	if !strings.HasPrefix(prepareString(s), "@") {
+		return nil, src.notFoundError()
 	}
-	a := &ann{}
-	err := parse(a, s)
+	ps := parser{Parser: p, src: src, s: s}
+	if err := ps.next(); err != nil {
+		return nil, err
+	}
+	ant, err := ps.annotation()
 	if err != nil {
 		return nil, err
 	}
-	ant := NewAnnotation(a.Name)
-	for _, v := range a.Values {
-		ant.Set(v.Key, *v.Value)
+	if ps.tok.kind != tokenEOF {
+		return nil, ps.unexpected()
+	}
+	return &ant, nil
+}
+
+// ParseAll parses several annotations written in sequence e.x a comment block
+// with `@Get("/users")` and `@Auth(roles=["admin"])` on separate lines.
+//
+// An annotation that can not be parsed does not stop the parsing, it is skipped up to its closing `)`
+// or the next `@` and the annotations that could be parsed are returned together with an ErrorList
+// that has the error of every annotation that could not.
+func ParseAll(s string) ([]Annotation, error) {
+	return defaultParser.ParseAll(s)
+}
+
+// depthLimit returns the depth limit set with MaxDepth, the default one if it is not positive
+// and never more than hardMaxDepth.
+func (p *Parser) depthLimit() int {
+	switch {
+	case p.maxDepth <= 0:
+		return defaultMaxDepth
+	case p.maxDepth > hardMaxDepth:
+		return hardMaxDepth
+	}
+	return p.maxDepth
+}
+
+// ParseAll parses several annotations written in sequence the same way as the package level ParseAll.
+func (p *Parser) ParseAll(s string) ([]Annotation, error) {
+	src := newSource(s, Position{})
+	if p.maxLength > 0 && len(s) > p.maxLength {
+		return nil, ErrorList{src.limitError(0, LimitLength, p.maxLength, "annotations are longer than %d bytes")}
+	}
+	var annotations []Annotation
+	var errs ErrorList
+	parens := &parentheses{s: s}
+	n := 0
+	for i := skipSpace(s, 0); i < len(s); i = skipSpace(s, i) {
+		if s[i] != '@' {
+			errs = append(errs, src.unexpectedError(i, "`@`"))
+			i = nextAnnotation(s, i)
+			continue
+		}
+		if p.maxAnnotations > 0 && n == p.maxAnnotations {
+			errs = append(errs, src.limitError(i, LimitAnnotations, p.maxAnnotations, "there are more than %d annotations"))
+			break
+		}
+		n++
+		end := annotationEnd(parens, i)
+		a, err := p.parseIn(src, i, end)
+		if err != nil {
+			pe, ok := err.(*ParseError)
+			if !ok {
+				return nil, err
+			}
+			errs = append(errs, pe)
+		} else {
+			annotations = append(annotations, *a)
+		}
+		i = end
+	}
+	return annotations, errs.Err()
+}
+
+// parser is the recursive descent parser of a single annotation,
+// it reads the tokens of the text one at a time without going back.
+type parser struct {
+	*Parser
+	src *source
+	s   string
+
+	// tok is the current token and prev the end of the token before it.
+	tok  token
+	prev int
+
+	// depth is the number of lists, maps and annotations the current token is in.
+	depth int
+}
+
+// annotation parses `@Name` or `@Name(parameters)`, the current token is the `@`.
+func (p *parser) annotation() (Annotation, error) {
+	start := p.tok.start
+	if !p.is('@') {
+		return Annotation{}, p.unexpected("`@`")
+	}
+	if err := p.next(); err != nil {
+		return Annotation{}, err
+	}
+	if p.tok.kind != tokenIdent {
+		return Annotation{}, p.unexpected("a name")
+	}
+	ant := NewAnnotation(p.text())
+	if err := p.next(); err != nil {
+		return Annotation{}, err
+	}
+	if !p.is('(') {
+		if p.strict {
+			return Annotation{}, p.src.newError(p.tok.start, fmt.Sprintf("marker annotation `@%s` must have parentheses", ant.Name), "`(`")
+		}
+		ant.marker = true
+		ant.rng = p.src.span(start, p.prev)
+		return ant, nil
+	}
+	if err := p.next(); err != nil {
+		return Annotation{}, err
+	}
+	positional, n := -1, 0
+	var collected map[string]bool
+	if p.duplicates == DuplicateList {
+		collected = map[string]bool{}
+	}
+	for ; !p.is(')'); n++ {
+		if n > 0 {
+			if !p.is(',') {
+				return Annotation{}, p.unexpected("`,`", "`)`")
+			}
+			if err := p.next(); err != nil {
+				return Annotation{}, err
+			}
+		}
+		keyStart := p.tok.start
+		if p.maxParameters > 0 && n == p.maxParameters {
+			return Annotation{}, p.src.limitError(keyStart, LimitParameters, p.maxParameters, "annotation has more than %d parameters")
+		}
+		key, value, err := p.parameter()
+		if err != nil {
+			return Annotation{}, err
+		}
+		if key == "" {
+			if p.strict {
+				return Annotation{}, p.src.newError(keyStart, fmt.Sprintf("parameter of `@%s()` Annotation must have a name", ant.Name))
+			}
+			positional = keyStart
+		}
+		// the positional value is rejected before the parameters are set
+		// so `@A("a", value="b")` is not reported as a duplicate of a parameter that has no name
+		if positional >= 0 && n > 0 {
+			return Annotation{}, p.src.newError(positional, fmt.Sprintf("positional value in `@%s()` Annotation must be the only parameter", ant.Name))
+		}
+		if key != "" {
+			if err := p.setParameter(&ant, key, keyStart, value, collected); err != nil {
+				return Annotation{}, err
+			}
+			continue
+		}
+		ant.Set(DefaultParameter, value)
+	}
+	if err := p.next(); err != nil {
+		return Annotation{}, err
+	}
+	ant.rng = p.src.span(start, p.prev)
+	return ant, nil
+}
+
+// setParameter sets a named parameter of the annotation, a parameter that is already set is handled by the duplicate policy.
+// collected has the parameters whose values are already collected in a list.
+func (p *parser) setParameter(ant *Annotation, key string, keyStart int, value attrValue, collected map[string]bool) error {
+	old, ok := ant.parameters[key]
+	if !ok || p.duplicates == DuplicateLast {
+		ant.Set(key, value)
+		ant.setKeyRange(key, p.src.span(keyStart, keyStart+len(key)))
+		return nil
+	}
+	switch p.duplicates {
+	case DuplicateReject:
+		first := ant.KeyRange(key).Start
+		e := p.src.newError(keyStart, fmt.Sprintf("duplicate parameter `%s` in `@%s()` Annotation, first set at %s", key, ant.Name, first))
+		e.Err = &DuplicateError{Name: key, First: first}
+		return e
+	case DuplicateList:
+		if !collected[key] {
+			collected[key] = true
+			old = attrValue{L: []attrValue{old}, rng: old.rng}
+		}
+		old.L = append(old.L, value)
+		old.rng.End = value.rng.End
+		ant.Set(key, old)
+	}
+	return nil
+}
+
+// parameter parses a `name=value` parameter or a positional value, the name is empty for a positional value.
+// The name and a positional identifier value both start with an identifier, they are told apart by the `=`.
+func (p *parser) parameter() (string, attrValue, error) {
+	if p.tok.kind != tokenIdent {
+		v, err := p.value()
+		return "", v, err
+	}
+	start := p.tok.start
+	ident, selector, err := p.ident()
+	if err != nil {
+		return "", attrValue{}, err
+	}
+	if !p.is('=') {
+		v, err := p.identValue(start, ident, selector)
+		return "", v, err
+	}
+	if selector {
+		return "", attrValue{}, p.src.newError(start, fmt.Sprintf("invalid parameter name `%s`", ident))
 	}
-	return &ant, err
+	if err := p.next(); err != nil {
+		return "", attrValue{}, err
+	}
+	v, err := p.value()
+	return ident, v, err
 }
 
-// parse is a helper function that builds the parser.
-func parse(a interface{}, s string) (err error) {
-	p, err := participle.Build(a)
+// value parses a parameter value.
+func (p *parser) value() (attrValue, error) {
+	start := p.tok.start
+	if p.is('[') || p.is('{') || p.is('@') {
+		p.depth++
+		defer func() { p.depth-- }()
+		if max := p.depthLimit(); p.depth > max {
+			return attrValue{}, p.src.limitError(start, LimitDepth, max, "value is nested deeper than %d levels")
+		}
+	}
+	v := attrValue{}
+	var err error
+	switch {
+	case p.tok.kind == tokenString:
+		var s string
+		if s, err = p.expand(p.tok.str); err != nil {
+			return attrValue{}, err
+		}
+		v.Str = &s
+		err = p.next()
+	case p.tok.kind == tokenIdent:
+		ident, selector, err := p.ident()
+		if err != nil {
+			return attrValue{}, err
+		}
+		return p.identValue(start, ident, selector)
+	case p.tok.kind == tokenInt || p.tok.kind == tokenFloat || p.tok.kind == tokenDuration || p.is('-') || p.is('+'):
+		err = p.number(&v)
+	case p.is('['):
+		err = p.list(&v)
+	case p.is('{'):
+		err = p.mapValue(&v)
+	case p.is('@'):
+		a, aErr := p.annotation()
+		v.A, err = &a, aErr
+	default:
+		return attrValue{}, p.unexpected("a value")
+	}
 	if err != nil {
+		return attrValue{}, err
+	}
+	v.rng = p.src.span(start, p.prev)
+	return v, p.checkType(v, start)
+}
+
+// ident parses an identifier with its selectors e.x `log.Debug`, selector tells if there is any.
+func (p *parser) ident() (ident string, selector bool, err error) {
+	start := p.tok.start
+	ident = p.text()
+	joined := false
+	for {
+		if err := p.next(); err != nil {
+			return "", false, err
+		}
+		if !p.is('.') {
+			return ident, selector, nil
+		}
+		gap := p.tok.start != p.prev
+		if err := p.next(); err != nil {
+			return "", false, err
+		}
+		if p.tok.kind != tokenIdent {
+			return "", false, p.unexpected("a name")
+		}
+		selector = true
+		// the string is only built if there is white space around the dots e.x `log . Debug`
+		if joined = joined || gap || p.tok.start != p.prev; joined {
+			ident += "." + p.text()
+		} else {
+			ident = p.s[start:p.tok.end]
+		}
+	}
+}
+
+// identValue returns the value of an identifier, `true`, `false`, `null` and `nil` are keywords.
+func (p *parser) identValue(start int, ident string, selector bool) (attrValue, error) {
+	v := attrValue{rng: p.src.span(start, p.prev)}
+	switch {
+	case selector:
+		v.Ident = &ident
+	case ident == "true":
+		v.VTrue = true
+	case ident == "false":
+		v.VFalse = true
+	case ident == "null" || ident == "nil":
+		v.Null = true
+	default:
+		v.Ident = &ident
+	}
+	return v, p.checkType(v, start)
+}
+
+// number parses a signed int, float or duration, ints that do not fit in an int64 are kept as a big.Int
+// and floats that do not fit in a float64 are kept as a big.Float.
+func (p *parser) number(v *attrValue) error {
+	start := p.tok.start
+	sign := ""
+	if p.is('-') || p.is('+') {
+		sign = p.text()
+		if err := p.next(); err != nil {
+			return err
+		}
+	}
+	s := sign + p.text()
+	switch p.tok.kind {
+	case tokenInt:
+		i, err := strconv.ParseInt(s, 0, 64)
+		if err == nil {
+			v.I = &i
+			break
+		}
+		b, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return p.src.newError(start, numberError(s, err))
+		}
+		v.B = b
+	case tokenFloat:
+		if err := v.setFloat(s); err != nil {
+			return p.src.newError(start, numberError(s, err))
+		}
+	case tokenDuration:
+		// the duration uses the time.ParseDuration rules
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return p.src.newError(start, fmt.Sprintf("invalid duration `%s`", s))
+		}
+		v.D = &d
+	default:
+		return p.unexpected("a number")
+	}
+	duration := v.D != nil
+	if err := p.next(); err != nil {
 		return err
 	}
-	if err := p.ParseString(s, a); err != nil {
+	// the unit has to be written right after the number e.x `5s` not `5 s`
+	if !duration && p.tok.kind == tokenIdent && isDurationUnit(p.text()) {
+		return p.src.newError(start, fmt.Sprintf("invalid duration `%s %s`", s, p.text()))
+	}
+	return nil
+}
+
+// isDurationUnit tells if s is a unit of a duration e.x `s` or `ms`.
+func isDurationUnit(s string) bool {
+	_, err := time.ParseDuration("1" + s)
+	return err == nil
+}
+
+// list parses a `[value, value]` list.
+func (p *parser) list(v *attrValue) error {
+	v.L = []attrValue{}
+	if err := p.next(); err != nil {
 		return err
 	}
-	return
+	for !p.is(']') {
+		if len(v.L) > 0 {
+			if !p.is(',') {
+				return p.unexpected("`,`", "`]`")
+			}
+			if err := p.next(); err != nil {
+				return err
+			}
+		}
+		e, err := p.value()
+		if err != nil {
+			return err
+		}
+		v.L = append(v.L, e)
+	}
+	return p.next()
+}
+
+// mapValue parses a `{key: value}` map, the keys are identifiers or strings.
+func (p *parser) mapValue(v *attrValue) error {
+	v.M = []mapEntry{}
+	if err := p.next(); err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	for !p.is('}') {
+		if len(v.M) > 0 {
+			if !p.is(',') {
+				return p.unexpected("`,`", "`}`")
+			}
+			if err := p.next(); err != nil {
+				return err
+			}
+		}
+		start := p.tok.start
+		var key string
+		switch p.tok.kind {
+		case tokenIdent:
+			key = p.text()
+		case tokenString:
+			key = p.tok.str
+		default:
+			return p.unexpected("a key")
+		}
+		if err := p.next(); err != nil {
+			return err
+		}
+		if !p.is(':') {
+			return p.unexpected("`:`")
+		}
+		if seen[key] {
+			return p.src.newError(start, fmt.Sprintf("duplicate key `%s` in map", key))
+		}
+		seen[key] = true
+		if err := p.next(); err != nil {
+			return err
+		}
+		e, err := p.value()
+		if err != nil {
+			return err
+		}
+		v.M = append(v.M, mapEntry{Key: key, Value: e})
+	}
+	return p.next()
+}
+
+// checkType returns an error if the type of the value is not allowed by the options of the Parser.
+func (p *parser) checkType(v attrValue, start int) error {
+	if p.types != nil && !p.types[v.Type()] {
+		return p.src.newError(start, fmt.Sprintf("%s values are not allowed", v.Type()))
+	}
+	return nil
+}
+
+// is tells if the current token is the character c.
+func (p *parser) is(c byte) bool {
+	return p.tok.kind == tokenChar && p.s[p.tok.start] == c
+}
+
+// text returns the text of the current token.
+func (p *parser) text() string {
+	return p.s[p.tok.start:p.tok.end]
+}
+
+// unexpected returns the error for an unexpected current token.
+func (p *parser) unexpected(expected ...string) error {
+	offset := p.tok.start
+	if p.tok.kind == tokenEOF {
+		// the end of the input is reported right after the last token, an annotation that is not closed
+		// and cut at the next `@` is not reported at the next annotation
+		offset = p.prev
+	}
+	e := p.src.newError(offset, "", expected...)
+	e.Unexpected = p.text()
+	e.Message = unexpectedMessage(e.Unexpected)
+	return e
 }
 
 func prepareString(s string) string {
 	return strings.TrimSpace(s)
 }
 
+// numberError returns the message for a number that can not be parsed.
+func numberError(s string, err error) string {
+	if e, ok := err.(*strconv.NumError); ok && e.Err == strconv.ErrRange {
+		return fmt.Sprintf("number `%s` is out of range", s)
+	}
+	return fmt.Sprintf("invalid number `%s`", s)
+}
+
+// setFloat sets the float value of v, F is set if the float fits in a float64
+// and BF keeps the value of the literal if F does not e.x `3.14159265358979323846264338327950288` or `1e999`.
+func (v *attrValue) setFloat(s string) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return err
+	}
+	b, _, bigErr := big.ParseFloat(s, 0, floatPrec(s), big.ToNearestEven)
+	if bigErr != nil {
+		// the exponent does not fit in a big.Float either
+		return &strconv.NumError{Func: "ParseFloat", Num: s, Err: strconv.ErrRange}
+	}
+	// a float that is not zero but rounds to zero e.x `1e-400` does not fit in a float64
+	if err == nil && (f != 0 || b.Sign() == 0) {
+		v.F = &f
+		if decimalFloat(f, b.Prec()).Cmp(b) == 0 {
+			return nil
+		}
+	}
+	v.BF = b
+	return nil
+}
+
+// floatPrec returns the precision used to keep a float literal, 4 bits for every character is enough for every digit.
+func floatPrec(s string) uint {
+	return 64 + 4*uint(len(s))
+}
+
+// decimalFloat returns the shortest decimal that reads back as f as a big.Float with the precision,
+// it is the value of the literal f was parsed from unless the literal has more digits than a float64 keeps.
+func decimalFloat(f float64, prec uint) *big.Float {
+	b, _, _ := big.ParseFloat(strconv.FormatFloat(f, 'g', -1, 64), 10, prec, big.ToNearestEven)
+	return b
+}
+
 func (v attrValue) String() string {
 	switch v.Type() {
 	case STRING:
 		return *v.Str
 	case INT:
-		return strconv.Itoa(*v.I)
+		return v.BigInt().String()
 	case FLOAT:
+		if v.F == nil {
+			return v.BF.Text('g', -1)
+		}
 		return strconv.FormatFloat(*v.F, 'f', 4, 64)
+	case DURATION:
+		return v.D.String()
 	case BOOL:
 		if v.VTrue {
 			return "true"
 		}
 		return "false"
+	case NULL:
+		return "null"
+	case IDENT:
+		return *v.Ident
+	case LIST, MAP, ANNOTATION:
+		return v.source(false)
 	default:
 		return ""
 	}
 }
 
+// source returns the value the way it would be written in an annotation,
+// if sorted is true the parameters of the nested annotations are sorted by name.
+func (v attrValue) source(sorted bool) string {
+	switch v.Type() {
+	case STRING:
+		return strconv.Quote(*v.Str)
+	case LIST:
+		s := make([]string, len(v.L))
+		for i, e := range v.L {
+			s[i] = e.source(sorted)
+		}
+		return "[" + strings.Join(s, ", ") + "]"
+	case MAP:
+		s := make([]string, len(v.M))
+		for i, e := range v.M {
+			s[i] = strconv.Quote(e.Key) + ": " + e.Value.source(sorted)
+		}
+		return "{" + strings.Join(s, ", ") + "}"
+	case ANNOTATION:
+		return v.A.format(sorted)
+	case FLOAT:
+		if v.BF != nil {
+			return floatSource(v.BF.Text('g', -1))
+		}
+		return floatSource(strconv.FormatFloat(*v.F, 'g', -1, 64))
+	}
+	return v.String()
+}
+
+// floatSource adds `.0` to a float formatted without a point or an exponent e.x `2` so it is not read back as an int.
+func floatSource(s string) string {
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
 func (v attrValue) Int() int {
+	i := v.Int64()
+	if int64(int(i)) != i {
+		return 0
+	}
+	return int(i)
+}
+
+func (v attrValue) Int64() int64 {
 	switch v.Type() {
 	case INT:
+		if v.I == nil {
+			return 0
+		}
 		return *v.I
 	case FLOAT:
-		return int(*v.F)
+		// a float that does not fit in an int64 e.x `1e300` is not converted
+		if i := v.BigInt(); i.IsInt64() {
+			return i.Int64()
+		}
+		return 0
+	default:
+		i, err := strconv.ParseInt(v.String(), 10, 64)
+		if err != nil {
+			return 0
+		}
+		return i
+	}
+}
+
+func (v attrValue) Uint64() uint64 {
+	switch v.Type() {
+	case INT, FLOAT:
+		if b := v.BigInt(); b.IsUint64() {
+			return b.Uint64()
+		}
+		return 0
+	default:
+		i, err := strconv.ParseUint(v.String(), 10, 64)
+		if err != nil {
+			return 0
+		}
+		return i
+	}
+}
+
+func (v attrValue) BigInt() *big.Int {
+	switch v.Type() {
+	case INT:
+		if v.B != nil {
+			return new(big.Int).Set(v.B)
+		}
+		return big.NewInt(*v.I)
+	case FLOAT:
+		i, _ := v.BigFloat().Int(nil)
+		return i
 	default:
-		i, _ := strconv.ParseInt(v.String(), 10, strconv.IntSize)
-		return int(i)
+		i, ok := new(big.Int).SetString(v.String(), 10)
+		if !ok {
+			return nil
+		}
+		return i
 	}
 }
 
 func (v attrValue) Float() float64 {
 	switch v.Type() {
 	case FLOAT:
+		if v.F == nil {
+			return 0
+		}
 		return *v.F
 	case INT:
-		return float64(*v.I)
+		f, _ := v.BigFloat().Float64()
+		return f
 	default:
 		f, _ := strconv.ParseFloat(v.String(), 64)
 		return f
 	}
 }
 
+func (v attrValue) BigFloat() *big.Float {
+	switch v.Type() {
+	case FLOAT:
+		if v.BF != nil {
+			return new(big.Float).Copy(v.BF)
+		}
+		return decimalFloat(*v.F, floatPrec(strconv.FormatFloat(*v.F, 'g', -1, 64)))
+	case INT:
+		return new(big.Float).SetInt(v.BigInt())
+	default:
+		f, _, err := big.ParseFloat(v.String(), 10, 64, big.ToNearestEven)
+		if err != nil {
+			return nil
+		}
+		return f
+	}
+}
+
+func (v attrValue) Duration() time.Duration {
+	switch v.Type() {
+	case DURATION:
+		return *v.D
+	default:
+		d, _ := time.ParseDuration(v.String())
+		return d
+	}
+}
+
 func (v attrValue) Bool() bool {
 	return v.VTrue
 }
 
+func (v attrValue) List() []Value {
+	if v.L == nil {
+		return nil
+	}
+	l := make([]Value, len(v.L))
+	for i, e := range v.L {
+		l[i] = e
+	}
+	return l
+}
+
+func (v attrValue) Map() map[string]Value {
+	if v.M == nil {
+		return nil
+	}
+	m := make(map[string]Value, len(v.M))
+	for _, e := range v.M {
+		m[e.Key] = e.Value
+	}
+	return m
+}
+
+func (v attrValue) Keys() []string {
+	if v.M == nil {
+		return nil
+	}
+	keys := make([]string, len(v.M))
+	for i, e := range v.M {
+		keys[i] = e.Key
+	}
+	return keys
+}
+
+func (v attrValue) Annotation() *Annotation {
+	return v.A
+}
+
+func (v attrValue) Range() Range {
+	return v.rng
+}
+
 func (v attrValue) Type() ValueType {
-	if v.I != nil {
+	if v.A != nil {
+		return ANNOTATION
+	} else if v.I != nil || v.B != nil {
 		return INT
-	} else if v.F != nil {
+	} else if v.F != nil || v.BF != nil {
 		return FLOAT
+	} else if v.D != nil {
+		return DURATION
 	} else if v.VTrue || v.VFalse {
 		return BOOL
 	} else if v.Str != nil {
 		return STRING
+	} else if v.L != nil {
+		return LIST
+	} else if v.M != nil {
+		return MAP
+	} else if v.Null {
+		return NULL
+	} else if v.Ident != nil {
+		return IDENT
 	}
 	return UNKNOWN
 }
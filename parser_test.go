@@ -1,8 +1,11 @@
 package annotation
 
 import (
+	"math/big"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestParse(t *testing.T) {
@@ -25,6 +28,17 @@ func TestParse(t *testing.T) {
 				parameters: map[string]attrValue{},
 			},
 		},
+		{
+			name: "Should parse marker annotation string without parentheses",
+			args: args{
+				s: "@Deprecated",
+			},
+			want: &Annotation{
+				Name:       "Deprecated",
+				parameters: map[string]attrValue{},
+				marker:     true,
+			},
+		},
 		{
 			name: "Should parse normal annotation string with parameters",
 			args: args{
@@ -46,6 +60,7 @@ func TestParse(t *testing.T) {
 						VTrue: true,
 					},
 				},
+				order: []string{"string", "int", "bool", "float"},
 			},
 		},
 		{
@@ -66,7 +81,285 @@ func TestParse(t *testing.T) {
 						Str: pointerString("3/27/2003"),
 					},
 				},
+				order: []string{"Name", "date"},
+			},
+		},
+		{
+			name: "Should parse list parameters",
+			args: args{
+				s: `@Route(methods=["GET", "POST"], ports=[80, 443], empty=[])`,
+			},
+			want: &Annotation{
+				Name: "Route",
+				parameters: map[string]attrValue{
+					"methods": {
+						L: []attrValue{
+							{Str: pointerString("GET")},
+							{Str: pointerString("POST")},
+						},
+					},
+					"ports": {
+						L: []attrValue{
+							{I: pointerInt(80)},
+							{I: pointerInt(443)},
+						},
+					},
+					"empty": {
+						L: []attrValue{},
+					},
+				},
+				order: []string{"methods", "ports", "empty"},
+			},
+		},
+		{
+			name: "Should parse nested annotation parameters",
+			args: args{
+				s: `@Endpoint(auth=@Auth(roles=["admin"], optional=false))`,
+			},
+			want: &Annotation{
+				Name: "Endpoint",
+				parameters: map[string]attrValue{
+					"auth": {
+						A: &Annotation{
+							Name: "Auth",
+							parameters: map[string]attrValue{
+								"roles": {
+									L: []attrValue{
+										{Str: pointerString("admin")},
+									},
+								},
+								"optional": {
+									VFalse: true,
+								},
+							},
+							order: []string{"roles", "optional"},
+						},
+					},
+				},
+				order: []string{"auth"},
+			},
+		},
+		{
+			name: "Should parse map parameters",
+			args: args{
+				s: `@Cache(headers={"X-Tenant": "a", maxAge: 30}, empty={})`,
+			},
+			want: &Annotation{
+				Name: "Cache",
+				parameters: map[string]attrValue{
+					"headers": {
+						M: []mapEntry{
+							{Key: "X-Tenant", Value: attrValue{Str: pointerString("a")}},
+							{Key: "maxAge", Value: attrValue{I: pointerInt(30)}},
+						},
+					},
+					"empty": {
+						M: []mapEntry{},
+					},
+				},
+				order: []string{"headers", "empty"},
+			},
+		},
+		{
+			name: "Should return an error if a map has duplicate keys",
+			args: args{
+				s: `@Cache(headers={a: 1, "a": 2})`,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Should parse Go numeric literals",
+			args: args{
+				s: `@Limit(delay=-1, bits=0xFF, n=1_000_000, mask=0b1010, mode=0o755, plus=+3, eps=1e-9, half=0x1p-1, neg=-2.5)`,
+			},
+			want: &Annotation{
+				Name: "Limit",
+				parameters: map[string]attrValue{
+					"delay": {I: pointerInt(-1)},
+					"bits":  {I: pointerInt(255)},
+					"n":     {I: pointerInt(1000000)},
+					"mask":  {I: pointerInt(10)},
+					"mode":  {I: pointerInt(493)},
+					"plus":  {I: pointerInt(3)},
+					"eps":   {F: pointerFloat(1e-9)},
+					"half":  {F: pointerFloat(0.5)},
+					"neg":   {F: pointerFloat(-2.5)},
+				},
+				order: []string{"delay", "bits", "n", "mask", "mode", "plus", "eps", "half", "neg"},
+			},
+		},
+		{
+			name: "Should parse ints that do not fit in an int64 without losing precision",
+			args: args{
+				s: "@Limit(n=18446744073709551615, big=-0x1_0000_0000_0000_0000_0000)",
+			},
+			want: &Annotation{
+				Name: "Limit",
+				parameters: map[string]attrValue{
+					"n":   {B: pointerBigInt("18446744073709551615")},
+					"big": {B: pointerBigInt("-0x100000000000000000000")},
+				},
+				order: []string{"n", "big"},
+			},
+		},
+		{
+			name: "Should parse floats that do not fit in a float64 without losing precision",
+			args: args{
+				s: "@Limit(pi=3.14159265358979323846264338327950288, huge=1e999, tiny=-1e-400)",
+			},
+			want: &Annotation{
+				Name: "Limit",
+				parameters: map[string]attrValue{
+					"pi": {
+						F:  pointerFloat(3.141592653589793),
+						BF: pointerBigFloat("3.14159265358979323846264338327950288"),
+					},
+					"huge": {BF: pointerBigFloat("1e999")},
+					"tiny": {BF: pointerBigFloat("-1e-400")},
+				},
+				order: []string{"pi", "huge", "tiny"},
+			},
+		},
+		{
+			name: "Should return an error if a float is out of range",
+			args: args{
+				s: "@Limit(n=1e99999999999999)",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Should return an error if a numeric literal is malformed",
+			args: args{
+				s: "@Limit(n=1__000)",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Should parse duration parameters",
+			args: args{
+				s: `@Cache(timeout=5s, ttl=1h30m, retry=-1.5ms, backoff=2h45m30.5s)`,
+			},
+			want: &Annotation{
+				Name: "Cache",
+				parameters: map[string]attrValue{
+					"timeout": {D: pointerDuration(5 * time.Second)},
+					"ttl":     {D: pointerDuration(90 * time.Minute)},
+					"retry":   {D: pointerDuration(-1500 * time.Microsecond)},
+					"backoff": {D: pointerDuration(2*time.Hour + 45*time.Minute + 30500*time.Millisecond)},
+				},
+				order: []string{"timeout", "ttl", "retry", "backoff"},
+			},
+		},
+		{
+			name: "Should return an error if the duration unit is not known",
+			args: args{
+				s: "@Cache(timeout=5x)",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Should return an error if the duration unit is not next to the number",
+			args: args{
+				s: "@Cache(timeout=5 s)",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Should parse identifier parameters",
+			args: args{
+				s: `@Route(method=GET, level=log.Debug, methods=[GET, http.MethodPost])`,
+			},
+			want: &Annotation{
+				Name: "Route",
+				parameters: map[string]attrValue{
+					"method": {Ident: pointerString("GET")},
+					"level":  {Ident: pointerString("log.Debug")},
+					"methods": {
+						L: []attrValue{
+							{Ident: pointerString("GET")},
+							{Ident: pointerString("http.MethodPost")},
+						},
+					},
+				},
+				order: []string{"method", "level", "methods"},
+			},
+		},
+		{
+			name: "Should return an error if a qualified identifier is malformed",
+			args: args{
+				s: "@Route(method=http.)",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Should parse null parameters",
+			args: args{
+				s: `@Field(default=null, other=nil)`,
+			},
+			want: &Annotation{
+				Name: "Field",
+				parameters: map[string]attrValue{
+					"default": {
+						Null: true,
+					},
+					"other": {
+						Null: true,
+					},
+				},
+				order: []string{"default", "other"},
+			},
+		},
+		{
+			name: "Should parse a positional value as the default parameter",
+			args: args{
+				s: `@Path("/users/{id}")`,
+			},
+			want: &Annotation{
+				Name: "Path",
+				parameters: map[string]attrValue{
+					DefaultParameter: {
+						Str: pointerString("/users/{id}"),
+					},
+				},
+				order: []string{DefaultParameter},
+			},
+		},
+		{
+			name: "Should parse a positional bool value as the default parameter",
+			args: args{
+				s: `@Enabled(true)`,
+			},
+			want: &Annotation{
+				Name: "Enabled",
+				parameters: map[string]attrValue{
+					DefaultParameter: {
+						VTrue: true,
+					},
+				},
+				order: []string{DefaultParameter},
+			},
+		},
+		{
+			name: "Should return an error if a positional value is not the only parameter",
+			args: args{
+				s: `@Timeout(30, unit="s")`,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Should return an error if a list is malformed",
+			args: args{
+				s: `@Route(methods=["GET",])`,
 			},
+			want:    nil,
+			wantErr: true,
 		},
 		{
 			name: "Should return an error if annotation not found",
@@ -92,6 +385,9 @@ func TestParse(t *testing.T) {
 				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if got != nil {
+				*got = withoutRanges(*got)
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Parse() = %v, want %v", got, tt.want)
 			}
@@ -99,13 +395,126 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestParseAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []Annotation
+		wantErr bool
+	}{
+		{
+			name: "Should parse annotations on separate lines",
+			s: `@Get("/users")
+				@Auth(
+					roles=["admin"]
+				)
+				@Deprecated`,
+			want: []Annotation{
+				{
+					Name: "Get",
+					parameters: map[string]attrValue{
+						DefaultParameter: {Str: pointerString("/users")},
+					},
+					order: []string{DefaultParameter},
+				},
+				{
+					Name: "Auth",
+					parameters: map[string]attrValue{
+						"roles": {
+							L: []attrValue{
+								{Str: pointerString("admin")},
+							},
+						},
+					},
+					order: []string{"roles"},
+				},
+				{
+					Name:       "Deprecated",
+					parameters: map[string]attrValue{},
+					marker:     true,
+				},
+			},
+		},
+		{
+			name: "Should parse annotations on the same line",
+			s:    `@Get() @Cache(ttl=1)`,
+			want: []Annotation{
+				{
+					Name:       "Get",
+					parameters: map[string]attrValue{},
+				},
+				{
+					Name: "Cache",
+					parameters: map[string]attrValue{
+						"ttl": {I: pointerInt(1)},
+					},
+					order: []string{"ttl"},
+				},
+			},
+		},
+		{
+			name: "Should return no annotations for an empty string",
+			s:    "  ",
+			want: nil,
+		},
+		{
+			name:    "Should return an error if annotation not found",
+			s:       "No annotation here",
+			wantErr: true,
+		},
+		{
+			name: "Should return the annotations before a malformed one",
+			s:    "@Get()\n@Auth(roles=)",
+			want: []Annotation{
+				{
+					Name:       "Get",
+					parameters: map[string]attrValue{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return the annotations before text that is not an annotation",
+			s:    "@Get()\nsome text",
+			want: []Annotation{
+				{
+					Name:       "Get",
+					parameters: map[string]attrValue{},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAll(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for i := range got {
+				got[i] = withoutRanges(got[i])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_attrValue_String(t *testing.T) {
 	type fields struct {
 		Str    *string
-		I      *int
+		I      *int64
+		B      *big.Int
 		F      *float64
+		D      *time.Duration
 		VTrue  bool
 		VFalse bool
+		Null   bool
+		Ident  *string
+		L      []attrValue
+		M      []mapEntry
 	}
 	tests := []struct {
 		name   string
@@ -126,6 +535,13 @@ func Test_attrValue_String(t *testing.T) {
 			},
 			want: "3",
 		},
+		{
+			name: "Should return the string representation of big int if big int exists",
+			fields: fields{
+				B: pointerBigInt("18446744073709551616"),
+			},
+			want: "18446744073709551616",
+		},
 		{
 			name: "Should return the string representation of float if float exists",
 			fields: fields{
@@ -152,15 +568,62 @@ func Test_attrValue_String(t *testing.T) {
 			fields: fields{},
 			want:   "",
 		},
+		{
+			name: "Should return the string representation of list if list exists",
+			fields: fields{
+				L: []attrValue{
+					{Str: pointerString("GET")},
+					{I: pointerInt(80)},
+				},
+			},
+			want: `["GET", 80]`,
+		},
+		{
+			name: "Should return the string representation of map if map exists",
+			fields: fields{
+				M: []mapEntry{
+					{Key: "X-Tenant", Value: attrValue{Str: pointerString("a")}},
+					{Key: "maxAge", Value: attrValue{I: pointerInt(30)}},
+				},
+			},
+			want: `{"X-Tenant": "a", "maxAge": 30}`,
+		},
+		{
+			name: "Should return null if null exists",
+			fields: fields{
+				Null: true,
+			},
+			want: "null",
+		},
+		{
+			name: "Should return the string representation of duration if duration exists",
+			fields: fields{
+				D: pointerDuration(90 * time.Minute),
+			},
+			want: "1h30m0s",
+		},
+		{
+			name: "Should return the identifier if ident exists",
+			fields: fields{
+				Ident: pointerString("log.Debug"),
+			},
+			want: "log.Debug",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			v := attrValue{
 				Str:    tt.fields.Str,
 				I:      tt.fields.I,
+				B:      tt.fields.B,
 				F:      tt.fields.F,
+				D:      tt.fields.D,
 				VTrue:  tt.fields.VTrue,
 				VFalse: tt.fields.VFalse,
+				Null:   tt.fields.Null,
+				Ident:  tt.fields.Ident,
+				L:      tt.fields.L,
+				M:      tt.fields.M,
 			}
 			if got := v.String(); got != tt.want {
 				t.Errorf("attrValue.String() = %v, want %v", got, tt.want)
@@ -172,7 +635,7 @@ func Test_attrValue_String(t *testing.T) {
 func Test_attrValue_Int(t *testing.T) {
 	type fields struct {
 		Str    *string
-		I      *int
+		I      *int64
 		F      *float64
 		VTrue  bool
 		VFalse bool
@@ -244,8 +707,9 @@ func Test_attrValue_Int(t *testing.T) {
 func Test_attrValue_Float(t *testing.T) {
 	type fields struct {
 		Str    *string
-		I      *int
+		I      *int64
 		F      *float64
+		BF     *big.Float
 		VTrue  bool
 		VFalse bool
 	}
@@ -268,6 +732,13 @@ func Test_attrValue_Float(t *testing.T) {
 			},
 			want: 4.0,
 		},
+		{
+			name: "Should return zero if the float does not fit in a float64",
+			fields: fields{
+				BF: pointerBigFloat("1e999"),
+			},
+			want: 0,
+		},
 		{
 			name: "Should return float value for any string representation of float ",
 			fields: fields{
@@ -303,6 +774,7 @@ func Test_attrValue_Float(t *testing.T) {
 				Str:    tt.fields.Str,
 				I:      tt.fields.I,
 				F:      tt.fields.F,
+				BF:     tt.fields.BF,
 				VTrue:  tt.fields.VTrue,
 				VFalse: tt.fields.VFalse,
 			}
@@ -313,10 +785,227 @@ func Test_attrValue_Float(t *testing.T) {
 	}
 }
 
+func Test_attrValue_Int64(t *testing.T) {
+	tests := []struct {
+		name  string
+		value attrValue
+		want  int64
+	}{
+		{
+			name:  "Should return the int64 if int value exists",
+			value: attrValue{I: pointerInt(-9223372036854775808)},
+			want:  -9223372036854775808,
+		},
+		{
+			name:  "Should return the int64 if float exists",
+			value: attrValue{F: pointerFloat(4.6)},
+			want:  4,
+		},
+		{
+			name:  "Should return zero if the float does not fit in an int64",
+			value: attrValue{F: pointerFloat(1e300)},
+			want:  0,
+		},
+		{
+			name:  "Should return zero if the float does not fit in a float64",
+			value: attrValue{BF: pointerBigFloat("-1e999")},
+			want:  0,
+		},
+		{
+			name:  "Should return the int64 value for any string representation of int",
+			value: attrValue{Str: pointerString("3")},
+			want:  3,
+		},
+		{
+			name:  "Should return zero if the int does not fit in an int64",
+			value: attrValue{B: pointerBigInt("18446744073709551615")},
+			want:  0,
+		},
+		{
+			name:  "Should return zero if the string does not fit in an int64",
+			value: attrValue{Str: pointerString("18446744073709551615")},
+			want:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.value.Int64(); got != tt.want {
+				t.Errorf("attrValue.Int64() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_attrValue_Uint64(t *testing.T) {
+	tests := []struct {
+		name  string
+		value attrValue
+		want  uint64
+	}{
+		{
+			name:  "Should return the uint64 if int value exists",
+			value: attrValue{I: pointerInt(42)},
+			want:  42,
+		},
+		{
+			name:  "Should return the uint64 if a big int value fits",
+			value: attrValue{B: pointerBigInt("18446744073709551615")},
+			want:  18446744073709551615,
+		},
+		{
+			name:  "Should return zero for negative ints",
+			value: attrValue{I: pointerInt(-1)},
+			want:  0,
+		},
+		{
+			name:  "Should return zero for negative floats",
+			value: attrValue{F: pointerFloat(-1.5)},
+			want:  0,
+		},
+		{
+			name:  "Should return zero if the float does not fit in an uint64",
+			value: attrValue{F: pointerFloat(1e300)},
+			want:  0,
+		},
+		{
+			name:  "Should return the uint64 if a float fits",
+			value: attrValue{F: pointerFloat(1.8e19)},
+			want:  18000000000000000000,
+		},
+		{
+			name:  "Should return the uint64 value for any string representation of uint64",
+			value: attrValue{Str: pointerString("18446744073709551615")},
+			want:  18446744073709551615,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.value.Uint64(); got != tt.want {
+				t.Errorf("attrValue.Uint64() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_attrValue_BigInt(t *testing.T) {
+	tests := []struct {
+		name  string
+		value attrValue
+		want  *big.Int
+	}{
+		{
+			name:  "Should return the big int if a big int value exists",
+			value: attrValue{B: pointerBigInt("123456789012345678901234567890")},
+			want:  pointerBigInt("123456789012345678901234567890"),
+		},
+		{
+			name:  "Should return the big int if int value exists",
+			value: attrValue{I: pointerInt(-3)},
+			want:  big.NewInt(-3),
+		},
+		{
+			name:  "Should return the big int if float exists",
+			value: attrValue{F: pointerFloat(4.6)},
+			want:  big.NewInt(4),
+		},
+		{
+			name:  "Should return nil for all other cases",
+			value: attrValue{Str: pointerString("test")},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.value.BigInt()
+			if (got == nil) != (tt.want == nil) || got != nil && got.Cmp(tt.want) != 0 {
+				t.Errorf("attrValue.BigInt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_attrValue_BigFloat(t *testing.T) {
+	tests := []struct {
+		name  string
+		value attrValue
+		want  string
+	}{
+		{
+			name:  "Should return the big float of a big int without losing precision",
+			value: attrValue{B: pointerBigInt("123456789012345678901234567890")},
+			want:  "123456789012345678901234567890",
+		},
+		{
+			name:  "Should return the big float if float exists",
+			value: attrValue{F: pointerFloat(2.5)},
+			want:  "2.5",
+		},
+		{
+			name: "Should return the big float of a float without losing precision",
+			value: attrValue{
+				F:  pointerFloat(3.141592653589793),
+				BF: pointerBigFloat("3.14159265358979323846264338327950288"),
+			},
+			want: "3.14159265358979323846264338327950288",
+		},
+		{
+			name:  "Should return the big float of a float that does not fit in a float64",
+			value: attrValue{BF: pointerBigFloat("-1e-400")},
+			want:  "-0." + strings.Repeat("0", 399) + "1",
+		},
+		{
+			name:  "Should return the big float value for any string representation of a number",
+			value: attrValue{Str: pointerString("1.25")},
+			want:  "1.25",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.value.BigFloat().Text('f', -1); got != tt.want {
+				t.Errorf("attrValue.BigFloat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := (attrValue{Str: pointerString("test")}).BigFloat(); got != nil {
+		t.Errorf("attrValue.BigFloat() = %v, want nil", got)
+	}
+}
+
+func Test_attrValue_Duration(t *testing.T) {
+	tests := []struct {
+		name  string
+		value attrValue
+		want  time.Duration
+	}{
+		{
+			name:  "Should return the duration if duration exists",
+			value: attrValue{D: pointerDuration(time.Minute)},
+			want:  time.Minute,
+		},
+		{
+			name:  "Should return the duration value for any string representation of duration",
+			value: attrValue{Str: pointerString("1h30m")},
+			want:  90 * time.Minute,
+		},
+		{
+			name:  "Should return zero for all other cases",
+			value: attrValue{I: pointerInt(5)},
+			want:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.value.Duration(); got != tt.want {
+				t.Errorf("attrValue.Duration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_attrValue_Bool(t *testing.T) {
 	type fields struct {
 		Str    *string
-		I      *int
+		I      *int64
 		F      *float64
 		VTrue  bool
 		VFalse bool
@@ -378,13 +1067,163 @@ func Test_attrValue_Bool(t *testing.T) {
 	}
 }
 
+func Test_attrValue_List(t *testing.T) {
+	tests := []struct {
+		name  string
+		value attrValue
+		want  []Value
+	}{
+		{
+			name: "Should return the list elements if list exists",
+			value: attrValue{
+				L: []attrValue{
+					{Str: pointerString("GET")},
+					{VTrue: true},
+				},
+			},
+			want: []Value{
+				attrValue{Str: pointerString("GET")},
+				attrValue{VTrue: true},
+			},
+		},
+		{
+			name: "Should return an empty list if the list is empty",
+			value: attrValue{
+				L: []attrValue{},
+			},
+			want: []Value{},
+		},
+		{
+			name: "Should return nil for all other cases",
+			value: attrValue{
+				Str: pointerString("test"),
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.value.List(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("attrValue.List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_attrValue_Map(t *testing.T) {
+	tests := []struct {
+		name  string
+		value attrValue
+		want  map[string]Value
+	}{
+		{
+			name: "Should return the map entries if map exists",
+			value: attrValue{
+				M: []mapEntry{
+					{Key: "a", Value: attrValue{Str: pointerString("b")}},
+				},
+			},
+			want: map[string]Value{
+				"a": attrValue{Str: pointerString("b")},
+			},
+		},
+		{
+			name: "Should return nil for all other cases",
+			value: attrValue{
+				Str: pointerString("test"),
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.value.Map(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("attrValue.Map() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_attrValue_Keys(t *testing.T) {
+	tests := []struct {
+		name  string
+		value attrValue
+		want  []string
+	}{
+		{
+			name: "Should return the map keys in order",
+			value: attrValue{
+				M: []mapEntry{
+					{Key: "z", Value: attrValue{I: pointerInt(1)}},
+					{Key: "a", Value: attrValue{I: pointerInt(2)}},
+				},
+			},
+			want: []string{"z", "a"},
+		},
+		{
+			name: "Should return nil for all other cases",
+			value: attrValue{
+				Str: pointerString("test"),
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.value.Keys(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("attrValue.Keys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_attrValue_Annotation(t *testing.T) {
+	tests := []struct {
+		name  string
+		value attrValue
+		want  *Annotation
+	}{
+		{
+			name: "Should return the annotation if annotation exists",
+			value: attrValue{
+				A: &Annotation{
+					Name: "Auth",
+				},
+			},
+			want: &Annotation{
+				Name: "Auth",
+			},
+		},
+		{
+			name: "Should return nil for all other cases",
+			value: attrValue{
+				Str: pointerString("test"),
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.value.Annotation(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("attrValue.Annotation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_attrValue_Type(t *testing.T) {
 	type fields struct {
 		Str    *string
-		I      *int
+		I      *int64
 		F      *float64
+		D      *time.Duration
 		VTrue  bool
 		VFalse bool
+		Null   bool
+		Ident  *string
+		L      []attrValue
+		M      []mapEntry
+		A      *Annotation
 	}
 	tests := []struct {
 		name   string
@@ -426,6 +1265,48 @@ func Test_attrValue_Type(t *testing.T) {
 			},
 			want: BOOL,
 		},
+		{
+			name: "Should return type of duration if duration exists",
+			fields: fields{
+				D: pointerDuration(time.Second),
+			},
+			want: DURATION,
+		},
+		{
+			name: "Should return type of null if null exists",
+			fields: fields{
+				Null: true,
+			},
+			want: NULL,
+		},
+		{
+			name: "Should return type of ident if ident exists",
+			fields: fields{
+				Ident: pointerString("GET"),
+			},
+			want: IDENT,
+		},
+		{
+			name: "Should return type of list if list exists",
+			fields: fields{
+				L: []attrValue{},
+			},
+			want: LIST,
+		},
+		{
+			name: "Should return type of map if map exists",
+			fields: fields{
+				M: []mapEntry{},
+			},
+			want: MAP,
+		},
+		{
+			name: "Should return type of annotation if annotation exists",
+			fields: fields{
+				A: &Annotation{Name: "Auth"},
+			},
+			want: ANNOTATION,
+		},
 		{
 			name: "Should return type of unknown for any unknown case",
 			want: UNKNOWN,
@@ -437,8 +1318,14 @@ func Test_attrValue_Type(t *testing.T) {
 				Str:    tt.fields.Str,
 				I:      tt.fields.I,
 				F:      tt.fields.F,
+				D:      tt.fields.D,
 				VTrue:  tt.fields.VTrue,
 				VFalse: tt.fields.VFalse,
+				Null:   tt.fields.Null,
+				Ident:  tt.fields.Ident,
+				L:      tt.fields.L,
+				M:      tt.fields.M,
+				A:      tt.fields.A,
 			}
 			if got := v.Type(); got != tt.want {
 				t.Errorf("attrValue.Type() = %v, want %v", got, tt.want)
@@ -447,12 +1334,8 @@ func Test_attrValue_Type(t *testing.T) {
 	}
 }
 
-func Test_parse(t *testing.T) {
-	type ErrStruct struct {
-		Something string
-	}
+func TestParse_syntax(t *testing.T) {
 	type args struct {
-		a interface{}
 		s string
 	}
 	tests := []struct {
@@ -460,27 +1343,10 @@ func Test_parse(t *testing.T) {
 		args    args
 		wantErr bool
 	}{
-		{
-			name:    "Should throw error if the structure provided does not have correct parser tags",
-			wantErr: true,
-			args: args{
-				a: &ErrStruct{},
-				s: "",
-			},
-		},
-		{
-			name:    "Should throw error if the object sent is not a struct pointer",
-			wantErr: true,
-			args: args{
-				a: ErrStruct{},
-				s: "",
-			},
-		},
 		{
 			name:    "Should throw an error if the string that needs to be parsed does not have any annotation",
 			wantErr: true,
 			args: args{
-				a: &ann{},
 				s: "Some random string",
 			},
 		},
@@ -488,7 +1354,6 @@ func Test_parse(t *testing.T) {
 			name:    "Should not throw error if the string is an annotation",
 			wantErr: false,
 			args: args{
-				a: &ann{},
 				s: "@MyAnnotation()",
 			},
 		},
@@ -496,7 +1361,6 @@ func Test_parse(t *testing.T) {
 			name:    "Should parse string parameter",
 			wantErr: false,
 			args: args{
-				a: &ann{},
 				s: "@MyAnnotation(my_string=\"test\")",
 			},
 		},
@@ -504,7 +1368,6 @@ func Test_parse(t *testing.T) {
 			name:    "Should parse int parameter",
 			wantErr: false,
 			args: args{
-				a: &ann{},
 				s: "@MyAnnotation(my_int=2)",
 			},
 		},
@@ -512,18 +1375,23 @@ func Test_parse(t *testing.T) {
 			name:    "Should parse annotation multiple lines",
 			wantErr: false,
 			args: args{
-				a: &ann{},
 				s: `@Annotation(
 					Name = "Benjamin Franklin",
 					date = "3/27/2003"
 					)`,
 			},
 		},
+		{
+			name:    "Should parse negative int parameter",
+			wantErr: false,
+			args: args{
+				s: "@MyAnnotation(my_int=-2)",
+			},
+		},
 		{
 			name:    "Should parse float parameter",
 			wantErr: false,
 			args: args{
-				a: &ann{},
 				s: "@MyAnnotation(my_float=2.2)",
 			},
 		},
@@ -531,7 +1399,6 @@ func Test_parse(t *testing.T) {
 			name:    "Should parse bool parameter",
 			wantErr: false,
 			args: args{
-				a: &ann{},
 				s: "@MyAnnotation(my_bool=true)",
 			},
 		},
@@ -539,23 +1406,49 @@ func Test_parse(t *testing.T) {
 			name:    "Should parse multiple parameter",
 			wantErr: false,
 			args: args{
-				a: &ann{},
 				s: "@MyAnnotation(my_string=\"test\",my_int=2,my_float=2.2,my_bool=true)",
 			},
 		},
+		{
+			name:    "Should parse annotation without parentheses",
+			wantErr: false,
+			args: args{
+				s: "@MyAnnotation",
+			},
+		},
+		{
+			name:    "Should throw error if the parentheses are not closed",
+			wantErr: true,
+			args: args{
+				s: "@MyAnnotation(",
+			},
+		},
+		{
+			name:    "Should parse positional parameter",
+			wantErr: false,
+			args: args{
+				s: "@MyAnnotation(30)",
+			},
+		},
+		{
+			name:    "Should parse list parameter",
+			wantErr: false,
+			args: args{
+				s: "@MyAnnotation(my_list=[1, 2.2, \"test\", [true]])",
+			},
+		},
 		{
 			name:    "Should allow single quote string parameter",
 			wantErr: false,
 			args: args{
-				a: &ann{},
 				s: "@MyAnnotation(my_string='test')",
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := parse(tt.args.a, tt.args.s); (err != nil) != tt.wantErr {
-				t.Errorf("parse() error = %v, wantErr %v", err, tt.wantErr)
+			if _, err := Parse(tt.args.s); (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -594,14 +1487,66 @@ func Test_prepareString(t *testing.T) {
 	}
 }
 
+// withoutRanges returns a copy of a parsed annotation without the source ranges,
+// the ranges are tested separately so the other tests do not have to list them.
+func withoutRanges(a Annotation) Annotation {
+	a.rng = Range{}
+	a.keys = nil
+	if a.parameters != nil {
+		parameters := make(map[string]attrValue, len(a.parameters))
+		for k, v := range a.parameters {
+			parameters[k] = valueWithoutRanges(v)
+		}
+		a.parameters = parameters
+	}
+	return a
+}
+
+func valueWithoutRanges(v attrValue) attrValue {
+	v.rng = Range{}
+	if v.L != nil {
+		l := make([]attrValue, len(v.L))
+		for i, e := range v.L {
+			l[i] = valueWithoutRanges(e)
+		}
+		v.L = l
+	}
+	if v.M != nil {
+		m := make([]mapEntry, len(v.M))
+		for i, e := range v.M {
+			m[i] = mapEntry{Key: e.Key, Value: valueWithoutRanges(e.Value)}
+		}
+		v.M = m
+	}
+	if v.A != nil {
+		a := withoutRanges(*v.A)
+		v.A = &a
+	}
+	return v
+}
+
 func pointerString(s string) *string {
 	return &s
 }
 
-func pointerInt(i int) *int {
+func pointerInt(i int64) *int64 {
 	return &i
 }
 
+func pointerBigInt(s string) *big.Int {
+	b, _ := new(big.Int).SetString(s, 0)
+	return b
+}
+
+func pointerBigFloat(s string) *big.Float {
+	b, _, _ := big.ParseFloat(s, 0, floatPrec(s), big.ToNearestEven)
+	return b
+}
+
+func pointerDuration(d time.Duration) *time.Duration {
+	return &d
+}
+
 func pointerFloat(f float64) *float64 {
 	return &f
 }
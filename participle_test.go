@@ -0,0 +1,398 @@
+package annotation
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"text/scanner"
+	"time"
+
+	"github.com/alecthomas/participle"
+	"github.com/alecthomas/participle/lexer"
+)
+
+// This file has the participle grammar that was used before the hand-written parser,
+// it is kept to compare the results and the speed of the two parsers.
+
+// participleParse parses an annotation with the participle grammar.
+func participleParse(s string) (*Annotation, error) {
+	src := newSource(s, Position{})
+	if !strings.HasPrefix(prepareString(s), "@") {
+		return nil, src.notFoundError()
+	}
+	a := &ann{}
+	if err := parse(a, s); err != nil {
+		return nil, src.parseError(err)
+	}
+	ant, err := a.annotation(src)
+	if err != nil {
+		return nil, err
+	}
+	return &ant, nil
+}
+
+// literal is a helper struct for the parser to parse a single parameter value.
+type literal struct {
+	Pos    lexer.Position
+	EndPos lexer.Position
+
+	Str    *string      `parser:"@String"`
+	RStr   *string      `parser:"| @RawString"`
+	Number *number      `parser:"| @@"`
+	VTrue  bool         `parser:"| @'true'"`
+	VFalse bool         `parser:"| @'false'"`
+	Null   bool         `parser:"| @('null' | 'nil')"`
+	Ident  *string      `parser:"| @(Ident {'.' Ident})"`
+	List   *listLiteral `parser:"| @@"`
+	Map    *mapLiteral  `parser:"| @@"`
+	Ann    *ann         `parser:"| @@"`
+}
+
+// number is a helper struct for the parser to parse signed Go numeric literals,
+// a number followed by a unit e.x `1h30m` is a duration.
+type number struct {
+	Pos   lexer.Position
+	Sign  string      `parser:"@('-' | '+')?"`
+	Int   *string     `parser:"( @Int"`
+	Float *string     `parser:"| @Float )"`
+	Unit  []*unitPart `parser:"@@*"`
+}
+
+// unitPart is a helper struct for the parser to parse the unit of a duration,
+// `1h30m` is lexed as the number `1` followed by the unit parts `h30m`.
+type unitPart struct {
+	Pos   lexer.Position
+	Value string `parser:"@(Ident | Int | Float)"`
+}
+
+// listLiteral is a helper struct for the parser to parse `[value, value]` lists.
+type listLiteral struct {
+	Values []*literal `parser:"'[' [@@ {',' @@}] ']'"`
+}
+
+// mapLiteral is a helper struct for the parser to parse `{key: value}` maps.
+type mapLiteral struct {
+	Entries []*mapEntryLiteral `parser:"'{' [@@ {',' @@}] '}'"`
+}
+
+// mapEntryLiteral is a helper struct for the parser to parse `key: value` map entries,
+// the `:` and the value are optional in the grammar so a missing one is reported where it is missing.
+type mapEntryLiteral struct {
+	Pos    lexer.Position
+	EndPos lexer.Position
+
+	Key   string   `parser:"(@String | @Ident)"`
+	Colon bool     `parser:"[ @':'"`
+	Value *literal `parser:"  @@? ]"`
+}
+
+// value is a helper struct for the parser to parse `parameter="value"`  pairs,
+// the key is omitted for a positional value e.x `@Path("/users")`.
+// The parameter name and a positional identifier value both start with an identifier,
+// they are told apart by the `=` so the grammar does not need any lookahead.
+type value struct {
+	Pos      lexer.Position
+	EndPos   lexer.Position
+	Name     *string  `parser:"( @Ident"`
+	Selector []string `parser:"  { '.' @Ident }"`
+	Assign   bool     `parser:"  [ @'='"`
+	Value    *literal `parser:"    @@? ]"`
+	Literal  *literal `parser:"| @@ )"`
+}
+
+// ann is the struct that is used to parse parameters in comments,
+// the parentheses can be omitted for marker annotations e.x `@Deprecated`.
+type ann struct {
+	Pos    lexer.Position
+	EndPos lexer.Position
+
+	Name   string   `parser:"'@' @Ident"`
+	Parens bool     `parser:"[ @'('"`
+	Values []*value `parser:"[@@{','@@}] ')' ]"`
+}
+
+// annParser is the participle parser of the ann grammar.
+var annParser = participle.MustBuild(&ann{}, participle.UseLookahead(1))
+
+// annotation converts the parsed ann to an Annotation.
+func (a *ann) annotation(src *source) (Annotation, error) {
+	ant := NewAnnotation(a.Name)
+	ant.marker = !a.Parens
+	ant.rng = src.span(a.Pos.Offset, a.EndPos.Offset)
+	for _, v := range a.Values {
+		name, lit, err := v.parameter(src)
+		if err != nil {
+			return Annotation{}, err
+		}
+		key := DefaultParameter
+		if name != nil {
+			key = *name
+		} else if len(a.Values) > 1 {
+			return Annotation{}, src.newError(v.Pos.Offset, fmt.Sprintf("positional value in `@%s()` Annotation must be the only parameter", a.Name))
+		}
+		value, err := lit.attrValue(src)
+		if err != nil {
+			return Annotation{}, err
+		}
+		ant.Set(key, value)
+		if name != nil {
+			ant.setKeyRange(key, src.span(v.Pos.Offset, v.Pos.Offset+len(key)))
+		}
+	}
+	return ant, nil
+}
+
+// parameter returns the parameter name and value, the name is nil for a positional value.
+func (v *value) parameter(src *source) (*string, *literal, error) {
+	if v.Literal != nil {
+		return nil, v.Literal, nil
+	}
+	if v.Assign {
+		if len(v.Selector) > 0 {
+			return nil, nil, src.newError(v.Pos.Offset, fmt.Sprintf("invalid parameter name `%s`", v.ident()))
+		}
+		if v.Value == nil {
+			return nil, nil, src.unexpectedError(v.EndPos.Offset, "a value")
+		}
+		return v.Name, v.Value, nil
+	}
+	lit := &literal{Pos: v.Pos, EndPos: v.EndPos}
+	switch ident := v.ident(); ident {
+	case "true":
+		lit.VTrue = true
+	case "false":
+		lit.VFalse = true
+	case "null", "nil":
+		lit.Null = true
+	default:
+		lit.Ident = &ident
+	}
+	return nil, lit, nil
+}
+
+// ident returns the identifier with its selectors e.x `log.Debug`.
+func (v *value) ident() string {
+	return strings.Join(append([]string{*v.Name}, v.Selector...), ".")
+}
+
+// parse is a helper function that parses s with the shared grammar.
+func parse(a *ann, s string) error {
+	return annParser.ParseString(s, a)
+}
+
+// attrValue converts the parsed literal to the value stored in the annotation.
+func (l *literal) attrValue(src *source) (attrValue, error) {
+	v := attrValue{
+		Str:    l.Str,
+		VTrue:  l.VTrue,
+		VFalse: l.VFalse,
+		Null:   l.Null,
+		Ident:  l.Ident,
+		rng:    src.span(l.Pos.Offset, l.EndPos.Offset),
+	}
+	if l.RStr != nil {
+		v.Str = l.RStr
+	}
+	if l.Number != nil {
+		if err := l.Number.set(&v, src); err != nil {
+			return attrValue{}, err
+		}
+	}
+	if l.List != nil {
+		v.L = make([]attrValue, len(l.List.Values))
+		for i, e := range l.List.Values {
+			value, err := e.attrValue(src)
+			if err != nil {
+				return attrValue{}, err
+			}
+			v.L[i] = value
+		}
+	}
+	if l.Map != nil {
+		v.M = make([]mapEntry, len(l.Map.Entries))
+		keys := map[string]bool{}
+		for i, e := range l.Map.Entries {
+			if !e.Colon {
+				return attrValue{}, src.unexpectedError(e.EndPos.Offset, "`:`")
+			}
+			if e.Value == nil {
+				return attrValue{}, src.unexpectedError(e.EndPos.Offset, "a value")
+			}
+			if keys[e.Key] {
+				return attrValue{}, src.newError(e.Pos.Offset, fmt.Sprintf("duplicate key `%s` in map", e.Key))
+			}
+			keys[e.Key] = true
+			value, err := e.Value.attrValue(src)
+			if err != nil {
+				return attrValue{}, err
+			}
+			v.M[i] = mapEntry{Key: e.Key, Value: value}
+		}
+	}
+	if l.Ann != nil {
+		a, err := l.Ann.annotation(src)
+		if err != nil {
+			return attrValue{}, err
+		}
+		v.A = &a
+	}
+	return v, nil
+}
+
+// set sets the int or float value of v, ints that do not fit in an int64 are kept as a big.Int
+// and floats that do not fit in a float64 are kept as a big.Float.
+func (n *number) set(v *attrValue, src *source) error {
+	if len(n.Unit) > 0 {
+		return n.setDuration(v, src)
+	}
+	if n.Int != nil {
+		s := n.Sign + *n.Int
+		i, err := strconv.ParseInt(s, 0, 64)
+		if err == nil {
+			v.I = &i
+			return nil
+		}
+		b, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return src.newError(n.Pos.Offset, numberError(s, err))
+		}
+		v.B = b
+		return nil
+	}
+	s := n.Sign + *n.Float
+	if err := v.setFloat(s); err != nil {
+		return src.newError(n.Pos.Offset, numberError(s, err))
+	}
+	return nil
+}
+
+// setDuration sets the duration value of v using the time.ParseDuration rules,
+// the unit has to be written right after the number e.x `5s` not `5 s`.
+func (n *number) setDuration(v *attrValue, src *source) error {
+	s := n.Sign
+	if n.Int != nil {
+		s += *n.Int
+	} else {
+		s += *n.Float
+	}
+	for _, u := range n.Unit {
+		if u.Pos.Offset != n.Pos.Offset+len(s) {
+			return src.newError(n.Pos.Offset, fmt.Sprintf("invalid duration `%s %s`", s, u.Value))
+		}
+		s += u.Value
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return src.newError(n.Pos.Offset, fmt.Sprintf("invalid duration `%s`", s))
+	}
+	v.D = &d
+	return nil
+}
+
+// parseError converts an error of the participle parser or lexer to a ParseError.
+func (s *source) parseError(err error) error {
+	switch e := err.(type) {
+	case participle.UnexpectedTokenError:
+		pe := s.newError(e.Unexpected.Pos.Offset, "")
+		if !e.Unexpected.EOF() {
+			pe.Unexpected = tokenText(e.Unexpected)
+		}
+		pe.Message = unexpectedMessage(pe.Unexpected)
+		if expected := expectedToken(e.Expected); expected != "" {
+			pe.Expected = []string{expected}
+		}
+		return pe
+	case participle.Error:
+		return s.newError(e.Token().Pos.Offset, e.Message())
+	default:
+		return err
+	}
+}
+
+// tokenText returns the token the way it is written in the parsed string.
+func tokenText(t lexer.Token) string {
+	switch t.Type {
+	case scanner.String:
+		return strconv.Quote(t.Value)
+	case scanner.RawString:
+		return "`" + t.Value + "`"
+	default:
+		return t.Value
+	}
+}
+
+// expectedToken converts the expected token of the participle parser e.x `")"` or `<ident>`
+// to the text used in the errors, it returns an empty string for anything that describes the grammar.
+func expectedToken(s string) string {
+	switch {
+	case s == "<ident>":
+		return "a name"
+	case len(s) > 2 && s[0] == '"' && s[len(s)-1] == '"' && !strings.ContainsAny(s[1:len(s)-1], `" `):
+		return "`" + s[1:len(s)-1] + "`"
+	default:
+		return ""
+	}
+}
+
+// corpus has the annotations used to compare the two parsers.
+var corpus = []struct {
+	name string
+	s    string
+}{
+	{name: "marker", s: `@Deprecated`},
+	{name: "positional", s: `@Path("/users/{id}")`},
+	{name: "parameters", s: `@Route(path="/users", method=GET, port=8080, weight=0.5, public=true, default=null)`},
+	{name: "numbers", s: `@Limit(min=-1, max=0xFF, n=1_000_000, eps=1e-9, id=18446744073709551616, ttl=1h30m)`},
+	{name: "list", s: `@Route(methods=["GET", "POST"], ports=[80, 443], nested=[[true], []])`},
+	{name: "map", s: `@Cache(headers={"X-Tenant": "a", maxAge: 30, empty: {}}, level=log.Debug)`},
+	{name: "nested", s: `@Endpoint(auth=@Auth(roles=["admin"], optional=false), cache=@Cache)`},
+	{name: "strings", s: "@Doc(text=\"say \\\"hi\\\"\\n\", raw=`a\\b`, single='it is')"},
+	{name: "multiline", s: "@Route(\n\tpath=\"/users\",\n\tmethods=[GET, POST]\n)"},
+	{name: "spaces", s: "  @Route ( path = \"/users\" , level = log . Debug )  "},
+	{name: "error", s: `@Route(path=)`},
+	{name: "error string", s: `@Route(path="/users)`},
+	{name: "error positional", s: `@Route("/users", "/groups")`},
+	{name: "error map", s: `@Cache(headers={a: 1, a: 2})`},
+	{name: "error range", s: `@Limit(max=1e99999999999999)`},
+	{name: "error text", s: `@Route() and text`},
+}
+
+func TestParse_participle(t *testing.T) {
+	for _, tt := range corpus {
+		t.Run(tt.name, func(t *testing.T) {
+			want, wantErr := participleParse(tt.s)
+			got, err := Parse(tt.s)
+			if (err != nil) != (wantErr != nil) {
+				t.Fatalf("Parse() error = %v, participle error %v", err, wantErr)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Parse() = %v, participle %v", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, bb := range corpus {
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = Parse(bb.s)
+			}
+		})
+	}
+}
+
+func BenchmarkParse_participle(b *testing.B) {
+	for _, bb := range corpus {
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = participleParse(bb.s)
+			}
+		})
+	}
+}
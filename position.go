@@ -0,0 +1,104 @@
+package annotation
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Position describes a position in the parsed string.
+type Position struct {
+	// Filename is the name of the file, it is empty if the position is not in a file.
+	Filename string
+
+	// Offset is the byte offset, starting at 0.
+	Offset int
+
+	// Line is the line number, starting at 1.
+	Line int
+
+	// Column is the column number in bytes, starting at 1.
+	Column int
+}
+
+// IsValid tells if the position is set.
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String returns the position in the form `file:line:column`, `line:column` if there is no file name.
+func (p Position) String() string {
+	s := fmt.Sprintf("%d:%d", p.Line, p.Column)
+	if p.Filename != "" {
+		s = p.Filename + ":" + s
+	}
+	return s
+}
+
+// Range is the start and end position of a parsed element, End is the position right after the element.
+type Range struct {
+	Start Position
+	End   Position
+}
+
+// source is the parsed string, it converts byte offsets to positions.
+type source struct {
+	text string
+
+	// base is the position of the start of the text.
+	base Position
+
+	// lines has the byte offset of the start of every line.
+	lines []int
+
+	// start is the byte offset in text of the parsed part, the offsets given to the methods are relative to it.
+	start int
+}
+
+func newSource(text string, base Position) *source {
+	if !base.IsValid() {
+		base = Position{Filename: base.Filename, Offset: base.Offset, Line: 1, Column: 1}
+	}
+	s := &source{text: text, base: base, lines: []int{0}}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			s.lines = append(s.lines, i+1)
+		}
+	}
+	return s
+}
+
+// from returns the same source with the parsed part starting at the byte offset.
+func (s *source) from(offset int) *source {
+	sub := *s
+	sub.start += offset
+	return &sub
+}
+
+// position returns the position of a byte offset in the text.
+func (s *source) position(offset int) Position {
+	offset += s.start
+	line := sort.Search(len(s.lines), func(i int) bool { return s.lines[i] > offset }) - 1
+	column := offset - s.lines[line] + 1
+	if line == 0 {
+		column += s.base.Column - 1
+	}
+	return Position{
+		Filename: s.base.Filename,
+		Offset:   s.base.Offset + offset,
+		Line:     s.base.Line + line,
+		Column:   column,
+	}
+}
+
+// span returns the range between two byte offsets,
+// the white space before the end offset is not part of the range.
+func (s *source) span(start, end int) Range {
+	for end > start && isSpace(s.text[s.start+end-1]) {
+		end--
+	}
+	return Range{Start: s.position(start), End: s.position(end)}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,109 @@
+package annotation
+
+import (
+	"testing"
+)
+
+func TestPosition_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		position Position
+		want     string
+	}{
+		{
+			name:     "Should return line and column",
+			position: Position{Offset: 3, Line: 1, Column: 4},
+			want:     "1:4",
+		},
+		{
+			name:     "Should return the file name, line and column",
+			position: Position{Filename: "service.go", Offset: 30, Line: 3, Column: 2},
+			want:     "service.go:3:2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.position.String(); got != tt.want {
+				t.Errorf("Position.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_source_position(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		base   Position
+		offset int
+		want   Position
+	}{
+		{
+			name:   "Should return the position on the first line",
+			text:   "@A(b=1)",
+			offset: 3,
+			want:   Position{Offset: 3, Line: 1, Column: 4},
+		},
+		{
+			name:   "Should return the position on other lines",
+			text:   "@A(\n\tb=1\n)",
+			offset: 5,
+			want:   Position{Offset: 5, Line: 2, Column: 2},
+		},
+		{
+			name:   "Should move the position by the base position",
+			text:   "@A(\n\tb=1\n)",
+			base:   Position{Filename: "a.go", Offset: 100, Line: 10, Column: 5},
+			offset: 5,
+			want:   Position{Filename: "a.go", Offset: 105, Line: 11, Column: 2},
+		},
+		{
+			name:   "Should move the column of the first line by the base position",
+			text:   "@A(b=1)",
+			base:   Position{Filename: "a.go", Offset: 100, Line: 10, Column: 5},
+			offset: 3,
+			want:   Position{Filename: "a.go", Offset: 103, Line: 10, Column: 8},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newSource(tt.text, tt.base).position(tt.offset); got != tt.want {
+				t.Errorf("source.position() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_ranges(t *testing.T) {
+	s := "  @Route(\n    path = \"/users\",\n    methods=[GET, 1s]\n)  "
+	a, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	methods := a.Get("methods").List()
+	tests := []struct {
+		name string
+		got  Range
+		want string
+	}{
+		{name: "annotation", got: a.Range(), want: "@Route(\n    path = \"/users\",\n    methods=[GET, 1s]\n)"},
+		{name: "key", got: a.KeyRange("path"), want: "path"},
+		{name: "value", got: a.Get("path").Range(), want: "\"/users\""},
+		{name: "list", got: a.Get("methods").Range(), want: "[GET, 1s]"},
+		{name: "list element", got: methods[0].Range(), want: "GET"},
+		{name: "duration list element", got: methods[1].Range(), want: "1s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s[tt.got.Start.Offset:tt.got.End.Offset]; got != tt.want {
+				t.Errorf("Range() points to %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if r := a.KeyRange("missing"); r.Start.IsValid() {
+		t.Errorf("KeyRange() of a missing parameter = %v, want an invalid range", r)
+	}
+	if want := (Position{Offset: 14, Line: 2, Column: 5}); a.KeyRange("path").Start != want {
+		t.Errorf("KeyRange().Start = %v, want %v", a.KeyRange("path").Start, want)
+	}
+}
@@ -0,0 +1,202 @@
+package annotation
+
+import (
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// defaultLookahead is the length of the text a Reader reads ahead if the Parser has no MaxLength.
+	defaultLookahead = 64 << 10
+
+	// chunkSize is the number of bytes a Reader reads at once.
+	chunkSize = 4096
+)
+
+// Reader reads annotations written in sequence from an io.Reader one at a time the same way as ParseAll,
+// it only keeps the text of the annotation that is being read in memory.
+//
+// The Reader does not read more than MaxLength bytes, 64 KB if the Parser has no MaxLength, to find the end of an annotation.
+// If the parentheses of the annotation are not closed by then the annotation is an error and the text up to the next `@` is skipped,
+// a quoted text that is not closed by then does not hide the `@` in it either.
+type Reader struct {
+	p *Parser
+	r io.Reader
+
+	// buf is the text that is read but not parsed yet and base its position in the input.
+	buf  string
+	base Position
+
+	// data holds the bytes of buf at its end, the bytes before it are parsed already.
+	data []byte
+	eof  bool
+	err  error
+
+	// skipping is set after text that is not an annotation, the text is skipped up to the next `@`.
+	skipping bool
+
+	// n is the number of annotations that are read, limited is set once MaxAnnotations is exceeded.
+	n       int
+	limited bool
+
+	// parens remembers the parentheses of buf across the calls to Read,
+	// so the text of annotations that are not closed e.x `@A( @A( @A(` is not scanned again and again.
+	parens parentheses
+}
+
+// NewReader creates a Reader that reads the annotations from r.
+func NewReader(r io.Reader) *Reader {
+	return defaultParser.NewReader(r)
+}
+
+// NewReader creates a Reader that reads the annotations from r with the options of the Parser.
+func (p *Parser) NewReader(r io.Reader) *Reader {
+	return &Reader{p: p, r: r, base: Position{Line: 1, Column: 1}}
+}
+
+// Read returns the next annotation, the positions of the annotation are positions in the whole input.
+// An annotation that can not be parsed is returned as a *ParseError and the next call reads the annotation after it.
+// Read returns io.EOF when there are no more annotations, if reading r fails the annotations
+// that are already read are returned first and then the error.
+// The annotation after MaxAnnotations annotations is an error caused by a *LimitError, the next calls return io.EOF.
+func (r *Reader) Read() (Annotation, error) {
+	if r.limited {
+		return Annotation{}, r.end()
+	}
+	for {
+		if r.skipping {
+			end := nextAnnotation(r.buf, -1)
+			if end == len(r.buf) && !r.done() {
+				// a quoted text that is not closed yet is kept, the `@` in it do not start annotations
+				if q := strings.IndexAny(r.buf, "\"`"); q >= 0 && len(r.buf)-q <= r.lookahead() {
+					end = q
+				}
+				r.consume(end)
+				r.fill()
+				continue
+			}
+			r.consume(end)
+			if r.buf == "" {
+				return Annotation{}, r.end()
+			}
+			r.skipping = false
+		}
+		r.consume(skipSpace(r.buf, 0))
+		if r.buf == "" {
+			if r.done() {
+				return Annotation{}, r.end()
+			}
+			r.fill()
+			continue
+		}
+		if r.buf[0] != '@' {
+			// the source only has the text that is skipped after the error, the rest of buf is not scanned
+			e := newSource(r.buf[:nextAnnotation(r.buf, -1)], r.base).unexpectedError(0, "`@`")
+			if len(e.Unexpected) == len(r.buf) && !r.done() {
+				r.fill()
+				continue
+			}
+			r.skipping = true
+			return Annotation{}, e
+		}
+		r.parens.s, r.parens.offset = r.buf, r.base.Offset
+		end, ok := closedEnd(&r.parens, 0)
+		if !ok {
+			end = nextAnnotation(r.buf, 0)
+		}
+		if r.more(end, ok) {
+			r.fill()
+			continue
+		}
+		if r.p.maxAnnotations > 0 && r.n == r.p.maxAnnotations {
+			r.limited = true
+			return Annotation{}, newSource(r.buf[:1], r.base).limitError(0, LimitAnnotations, r.p.maxAnnotations, "there are more than %d annotations")
+		}
+		r.n++
+		// the text up to the next `@` is skipped if the end of the annotation is not read yet
+		r.skipping = !ok && end == len(r.buf) && !r.done()
+		// the source only has the text of the annotation, the snippet of an error ends with it
+		a, err := r.p.parseIn(newSource(r.buf[:end], r.base), 0, end)
+		r.consume(end)
+		if err != nil {
+			return Annotation{}, err
+		}
+		return *a, nil
+	}
+}
+
+// more tells if more text has to be read to know where the annotation at the start of buf ends,
+// end and ok are the end of the annotation in buf and if it is closed.
+func (r *Reader) more(end int, ok bool) bool {
+	if r.done() || len(r.buf) > r.lookahead() {
+		return false
+	}
+	if ok {
+		// the annotation could go on with a name, white space or parentheses
+		return skipSpace(r.buf, end) == len(r.buf)
+	}
+	if c, _ := utf8.DecodeRuneInString(r.buf[1:]); len(r.buf) > 1 && c != '_' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+		// the annotation has no name and ends at the next `@`
+		return end == len(r.buf)
+	}
+	// the parentheses are not closed yet
+	return true
+}
+
+// lookahead returns the length of the text that is read to find the end of an annotation.
+func (r *Reader) lookahead() int {
+	if r.p.maxLength > 0 {
+		return r.p.maxLength
+	}
+	return defaultLookahead
+}
+
+// done tells if the whole input is read, because of its end or of a read error.
+func (r *Reader) done() bool {
+	return r.eof || r.err != nil
+}
+
+// end returns the error returned once all the annotations are read.
+func (r *Reader) end() error {
+	if r.err != nil {
+		return r.err
+	}
+	return io.EOF
+}
+
+// fill reads the next chunk of the input after buf.
+func (r *Reader) fill() {
+	// the bytes that are parsed already are dropped
+	n := copy(r.data, r.data[len(r.data)-len(r.buf):])
+	r.data = r.data[:n]
+	if cap(r.data)-n < chunkSize {
+		data := make([]byte, n, 2*cap(r.data)+chunkSize)
+		copy(data, r.data)
+		r.data = data
+	}
+	m, err := r.r.Read(r.data[n : n+chunkSize])
+	r.data = r.data[:n+m]
+	r.buf = string(r.data)
+	r.parens.prune(r.base.Offset)
+	if err == io.EOF {
+		r.eof = true
+	} else if err != nil {
+		r.err = err
+	}
+}
+
+// consume drops the first n bytes of buf and moves its position after them.
+func (r *Reader) consume(n int) {
+	for i := 0; i < n; i++ {
+		if r.buf[i] == '\n' {
+			r.base.Line++
+			r.base.Column = 1
+		} else {
+			r.base.Column++
+		}
+	}
+	r.base.Offset += n
+	r.buf = r.buf[n:]
+}
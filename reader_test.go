@@ -0,0 +1,214 @@
+package annotation
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestReader_Read(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+	}{
+		{
+			name: "Should read nothing from an empty input",
+			s:    "  \n ",
+		},
+		{
+			name: "Should read annotations on separate lines",
+			s:    "@Get(\"/users\")\n@Auth(roles=[\"admin\"])\n@Cache(ttl=5m)\n",
+		},
+		{
+			name: "Should read annotations that span several lines",
+			s:    "@Route(\n\tpath=\"/users\",\n\tmethods=[GET, POST]\n)\n@Deprecated\n@Internal",
+		},
+		{
+			name: "Should read marker annotations followed by parentheses on the next line",
+			s:    "@Get\n(\"/users\") @Deprecated",
+		},
+		{
+			name: "Should read nested annotations and parentheses in strings",
+			s:    "@Endpoint(auth=@Auth(roles=[\"(admin\"]), path=\")\")\n@Get",
+		},
+		{
+			name: "Should read the annotations after malformed ones",
+			s:    "@Get(path=)\n@Auth(roles=[\"admin\"])\n@Cache(ttl==1)\n@Deprecated",
+		},
+		{
+			name: "Should read the annotations after text and annotations that are not closed",
+			s:    "@Get(\"/users\")\nsome \"quoted @text\"\n@Auth(path=\"/users\"\n@1\n@Internal",
+		},
+		{
+			name: "Should read annotations with text blocks",
+			s:    "@Query(sql=\"\"\"\n  SELECT (\n  @Get\n\"\"\")\n@Internal",
+		},
+	}
+	readers := map[string]func(string) io.Reader{
+		"string":   func(s string) io.Reader { return strings.NewReader(s) },
+		"one byte": func(s string) io.Reader { return iotest.OneByteReader(strings.NewReader(s)) },
+	}
+	for _, tt := range tests {
+		for name, reader := range readers {
+			t.Run(tt.name+" from "+name, func(t *testing.T) {
+				want, err := ParseAll(tt.s)
+				var wantErrs []string
+				var list ErrorList
+				if errors.As(err, &list) {
+					for _, e := range list {
+						wantErrs = append(wantErrs, e.Error())
+					}
+				}
+				r := NewReader(reader(tt.s))
+				var got []Annotation
+				var gotErrs []string
+				for {
+					a, err := r.Read()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						gotErrs = append(gotErrs, err.Error())
+						continue
+					}
+					got = append(got, a)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("Reader.Read() = %v, want %v", got, want)
+				}
+				if !reflect.DeepEqual(gotErrs, wantErrs) {
+					t.Errorf("Reader.Read() errors = %v, want %v", gotErrs, wantErrs)
+				}
+			})
+		}
+	}
+}
+
+func TestReader_Read_error(t *testing.T) {
+	want := errors.New("read error")
+	r := NewReader(io.MultiReader(strings.NewReader("@Get(\"/users\") "), iotest.ErrReader(want)))
+	a, err := r.Read()
+	if err != nil || a.Name != "Get" {
+		t.Fatalf("Reader.Read() = %v, %v, want @Get", a, err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := r.Read(); err != want {
+			t.Errorf("Reader.Read() error = %v, want %v", err, want)
+		}
+	}
+}
+
+func TestReader_Read_maxLength(t *testing.T) {
+	r := NewParser(MaxLength(16)).NewReader(iotest.OneByteReader(strings.NewReader(`@Get(path="/users/{id}/groups")`)))
+	_, err := r.Read()
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Message != "annotation is longer than 16 bytes" {
+		t.Errorf("Reader.Read() error = %v, want the length error", err)
+	}
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestReader_Read_lookahead(t *testing.T) {
+	prose := strings.Repeat("some text that is not an annotation\n", 200000)
+	tests := []struct {
+		name    string
+		options []Option
+		s       string
+		max     int
+	}{
+		{
+			name: "Should not read more than the default lookahead for parentheses that are not closed",
+			s:    "@A(\n" + prose + "@B",
+			max:  defaultLookahead + 2*chunkSize,
+		},
+		{
+			name:    "Should not read more than the length limit for parentheses that are not closed",
+			options: []Option{MaxLength(1024)},
+			s:       "@A(\n" + prose + "@B",
+			max:     1024 + 2*chunkSize,
+		},
+		{
+			name: "Should not read more than the default lookahead for a quoted text that is not closed",
+			s:    "text \"" + prose + "@B",
+			max:  defaultLookahead + 2*chunkSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &countingReader{r: strings.NewReader(tt.s)}
+			r := NewParser(tt.options...).NewReader(c)
+			if _, err := r.Read(); err == nil {
+				t.Fatalf("Reader.Read() error = nil")
+			}
+			if c.n > tt.max {
+				t.Errorf("Reader.Read() read %d bytes, want at most %d", c.n, tt.max)
+			}
+			a, err := r.Read()
+			if err != nil || a.Name != "B" {
+				t.Fatalf("Reader.Read() = %v, %v, want @B", a, err)
+			}
+			if len(r.data) > tt.max || cap(r.data) > 2*tt.max {
+				t.Errorf("Reader buffer = %d bytes, want at most %d", len(r.data), tt.max)
+			}
+		})
+	}
+}
+
+func TestReader_Read_hostile(t *testing.T) {
+	n := 100000
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{
+			name: "Should read the annotations that are never closed in linear time",
+			s:    strings.Repeat("@A(", n),
+			want: n,
+		},
+		{
+			name: "Should read the nested annotations that are closed in linear time",
+			s:    strings.Repeat("@A(", n/10) + "x=" + strings.Repeat(")", n/10),
+			want: 1,
+		},
+		{
+			name: "Should read the markers and text in linear time",
+			s:    strings.Repeat("x @A ", n),
+			want: 2 * n,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			r := NewReader(strings.NewReader(tt.s))
+			got := 0
+			for ; got <= tt.want; got++ {
+				if _, err := r.Read(); err == io.EOF {
+					break
+				}
+			}
+			if got != tt.want {
+				t.Errorf("Reader.Read() returned %d times, want %d", got, tt.want)
+			}
+			// a scan of the whole lookahead on every call takes minutes here
+			if d := time.Since(start); d > 5*time.Second {
+				t.Errorf("Reader.Read() took %v, want less than 5s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,412 @@
+// Package scan finds the annotations in the doc comments of Go declarations,
+// struct fields, interface methods, type parameters, function and method parameters
+// and the entries of grouped const and var declarations.
+//
+// Usage
+//
+//	declarations, _ := scan.Dir("./service")
+//	for _, d := range declarations {
+//		for _, a := range d.Annotations {
+//			fmt.Printf("%s %s: %s\n", d.Kind, d.Name, a.String()) // method Get: @Get(value="/users")
+//		}
+//	}
+package scan
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-services/annotation"
+)
+
+// Kind is a string that tells the kind of the declaration
+type Kind string
+
+const (
+	// FUNC represents a function declaration
+	FUNC Kind = "func"
+
+	// METHOD represents a method declaration
+	METHOD Kind = "method"
+
+	// TYPE represents a type declaration that is not an interface
+	TYPE Kind = "type"
+
+	// INTERFACE represents an interface type declaration
+	INTERFACE Kind = "interface"
+
+	// CONST represents a const declaration
+	CONST Kind = "const"
+
+	// VAR represents a var declaration
+	VAR Kind = "var"
+
+	// FIELD represents a struct field
+	FIELD Kind = "field"
+
+	// TYPEPARAM represents a type parameter of a generic type or function
+	TYPEPARAM Kind = "typeparam"
+
+	// PARAM represents a parameter of a function or method e.x `id` in `func Get(/* @PathParam("id") */ id string)`
+	PARAM Kind = "param"
+)
+
+// Declaration is a Go declaration with the annotations in its doc comment.
+type Declaration struct {
+	// Kind is the kind of the declaration.
+	Kind Kind
+
+	// Name is the name of the declaration, it is empty for grouped const and var declarations e.x `const (...)`.
+	Name string
+
+	// Receiver is the receiver type name of a method e.x `Service` for `func (s *Service) Get()`,
+	// for interface methods it is the interface name.
+	Receiver string
+
+	// Parent is the enclosing declaration of struct fields, interface methods, type parameters, parameters
+	// and the entries of grouped const and var declarations, it is nil for top level declarations.
+	Parent *Declaration
+
+	// Package is the name of the package the declaration is in.
+	Package string
+
+	// Position is the position of the declaration.
+	Position token.Position
+
+	// Node is the declaration node e.x *ast.FuncDecl, *ast.TypeSpec, *ast.ValueSpec, *ast.GenDecl or *ast.Field.
+	Node ast.Node
+
+	// Annotations are the annotations found in the doc comment and the trailing line comment.
+	Annotations []annotation.Annotation
+}
+
+// File scans the declarations of a Go source file,
+// if src is nil the file is read from filename, otherwise src is used the same way as in go/parser.ParseFile.
+// The annotations are parsed by an annotation.Parser with the given options e.x annotation.MaxLength(1024).
+//
+// If some annotations can not be parsed the declarations are returned with an annotation.ErrorList
+// that has the errors with their positions in the file, see annotation.FindAll.
+func File(filename string, src interface{}, options ...annotation.Option) ([]Declaration, error) {
+	result, errs, err := file(annotation.NewParser(options...), filename, src)
+	if err != nil {
+		return nil, err
+	}
+	return result, errs.Err()
+}
+
+// file scans the declarations of a Go source file with the annotation parser p,
+// errs has the errors of the annotations that can not be parsed and err the error of the Go source.
+func file(p *annotation.Parser, filename string, src interface{}) (result []Declaration, errs annotation.ErrorList, err error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, errs = declarations(p, fset, f)
+	return result, errs, nil
+}
+
+// Dir scans the declarations of all the Go files in a directory, test files and sub directories are not scanned.
+// The annotations are parsed by an annotation.Parser with the given options the same way as in File,
+// the annotation.ErrorList has the errors of the annotations of every file.
+func Dir(dir string, options ...annotation.Option) ([]Declaration, error) {
+	p := annotation.NewParser(options...)
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".go") || strings.HasSuffix(f.Name(), "_test.go") {
+			continue
+		}
+		names = append(names, f.Name())
+	}
+	sort.Strings(names)
+	var result []Declaration
+	var errs annotation.ErrorList
+	for _, name := range names {
+		d, fileErrs, err := file(p, filepath.Join(dir, name), nil)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, d...)
+		errs = append(errs, fileErrs...)
+	}
+	return result, errs.Err()
+}
+
+// declarations returns the declarations of a parsed file in source order,
+// the enclosed declarations e.x struct fields follow their parent.
+// errs has the errors of the annotations that can not be parsed.
+func declarations(p *annotation.Parser, fset *token.FileSet, f *ast.File) ([]Declaration, annotation.ErrorList) {
+	s := &scanner{p: p, fset: fset, file: f}
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil || len(d.Recv.List) == 0 {
+				fn := s.add(nil, FUNC, d.Name.Name, d, d.Doc)
+				s.params(fn, TYPEPARAM, d.Type.TypeParams)
+				s.params(fn, PARAM, d.Type.Params)
+				continue
+			}
+			method := s.declaration(nil, METHOD, d.Name.Name, d, d.Doc)
+			method.Receiver = receiverName(d.Recv.List[0].Type)
+			s.result = append(s.result, method)
+			s.params(&method, PARAM, d.Type.Params)
+		case *ast.GenDecl:
+			grouped := d.Lparen.IsValid()
+			switch d.Tok {
+			case token.TYPE:
+				for _, spec := range d.Specs {
+					t := spec.(*ast.TypeSpec)
+					doc := t.Doc
+					if !grouped {
+						doc = d.Doc
+					}
+					kind := TYPE
+					if _, ok := t.Type.(*ast.InterfaceType); ok {
+						kind = INTERFACE
+					}
+					parent := s.add(nil, kind, t.Name.Name, t, doc, t.Comment)
+					s.params(parent, TYPEPARAM, t.TypeParams)
+					s.members(parent, t.Type)
+				}
+			case token.CONST, token.VAR:
+				kind := CONST
+				if d.Tok == token.VAR {
+					kind = VAR
+				}
+				var parent *Declaration
+				if grouped {
+					parent = s.add(nil, kind, "", d, d.Doc)
+				}
+				for _, spec := range d.Specs {
+					v := spec.(*ast.ValueSpec)
+					doc := v.Doc
+					if !grouped {
+						doc = d.Doc
+					}
+					for _, name := range v.Names {
+						s.add(parent, kind, name.Name, v, doc, v.Comment)
+					}
+				}
+			}
+		}
+	}
+	return s.result, s.errs
+}
+
+// scanner collects the declarations of a file.
+type scanner struct {
+	p      *annotation.Parser
+	fset   *token.FileSet
+	file   *ast.File
+	result []Declaration
+
+	// errs has the errors of the annotations that can not be parsed.
+	errs annotation.ErrorList
+}
+
+// declaration creates a declaration with the annotations found in the comments.
+func (s *scanner) declaration(parent *Declaration, kind Kind, name string, node ast.Node, comments ...*ast.CommentGroup) Declaration {
+	var a []annotation.Annotation
+	for _, c := range comments {
+		a = append(a, s.annotations(c)...)
+	}
+	return Declaration{
+		Kind:        kind,
+		Name:        name,
+		Parent:      parent,
+		Package:     s.file.Name.Name,
+		Position:    s.fset.Position(node.Pos()),
+		Node:        node,
+		Annotations: a,
+	}
+}
+
+// add adds a declaration to the result and returns a copy that can be used as a parent.
+func (s *scanner) add(parent *Declaration, kind Kind, name string, node ast.Node, comments ...*ast.CommentGroup) *Declaration {
+	d := s.declaration(parent, kind, name, node, comments...)
+	s.result = append(s.result, d)
+	return &d
+}
+
+// members adds the fields of a struct type and the methods of an interface type,
+// the fields of nested struct types are added with the field as their parent.
+func (s *scanner) members(parent *Declaration, expr ast.Expr) {
+	switch t := expr.(type) {
+	case *ast.StructType:
+		for _, field := range t.Fields.List {
+			names := fieldNames(field)
+			for _, name := range names {
+				f := s.add(parent, FIELD, name, field, field.Doc, field.Comment)
+				s.members(f, field.Type)
+			}
+		}
+	case *ast.InterfaceType:
+		for _, field := range t.Methods.List {
+			fn, ok := field.Type.(*ast.FuncType)
+			if !ok {
+				continue
+			}
+			for _, name := range field.Names {
+				method := s.declaration(parent, METHOD, name.Name, field, field.Doc, field.Comment)
+				method.Receiver = parent.Name
+				s.result = append(s.result, method)
+				s.params(&method, PARAM, fn.Params)
+			}
+		}
+	}
+}
+
+// params adds the type parameters of a generic type or function or the parameters of a function or method,
+// go/ast does not attach comments to parameters so they are found by their position,
+// a comment on the same line after a parameter belongs to it, any other comment belongs to the next one.
+// An unnamed parameter e.x `func(string)` is added without a name.
+func (s *scanner) params(parent *Declaration, kind Kind, params *ast.FieldList) {
+	if params == nil || !params.Opening.IsValid() {
+		return
+	}
+	comments := make([][]*ast.CommentGroup, len(params.List))
+	for _, c := range s.file.Comments {
+		if c.Pos() < params.Opening || c.End() > params.Closing {
+			continue
+		}
+		for i, field := range params.List {
+			if field.End() <= c.Pos() && s.line(field.End()) == s.line(c.Pos()) {
+				comments[i] = append(comments[i], c)
+				break
+			}
+			if c.End() <= field.Pos() {
+				comments[i] = append(comments[i], c)
+				break
+			}
+		}
+	}
+	for i, field := range params.List {
+		if len(field.Names) == 0 {
+			s.add(parent, kind, "", field, comments[i]...)
+		}
+		for _, name := range field.Names {
+			s.add(parent, kind, name.Name, field, comments[i]...)
+		}
+	}
+}
+
+func (s *scanner) line(pos token.Pos) int {
+	return s.fset.Position(pos).Line
+}
+
+// fieldNames returns the names of a struct field, an embedded field is named after its type.
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) == 0 {
+		return []string{receiverName(field.Type)}
+	}
+	names := make([]string, len(field.Names))
+	for i, n := range field.Names {
+		names[i] = n.Name
+	}
+	return names
+}
+
+// receiverName returns the type name of a method receiver e.x `Service` for `*Service` or `Service[T]`,
+// it is also used for the names of embedded fields e.x `Mutex` for `sync.Mutex`.
+func receiverName(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.SelectorExpr:
+			expr = e.Sel
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// annotations returns the annotations found in a comment group,
+// the positions of the annotations are positions in the file.
+func (s *scanner) annotations(doc *ast.CommentGroup) []annotation.Annotation {
+	if doc == nil {
+		return nil
+	}
+	text, base := s.commentText(doc)
+	matches, err := s.p.FindAll(text, base)
+	if errs, ok := err.(annotation.ErrorList); ok {
+		s.errs = append(s.errs, errs...)
+	}
+	var result []annotation.Annotation
+	for _, m := range matches {
+		result = append(result, m.Annotation)
+	}
+	return result
+}
+
+// commentText returns the text of a comment group with the comment markers replaced by spaces,
+// the leading `*` of the lines in `/* */` comments is replaced as well.
+// The text starts at the beginning of the line of the first comment and keeps the layout of the file
+// so the returned base position can be used to get the file positions of the text.
+func (s *scanner) commentText(doc *ast.CommentGroup) (string, annotation.Position) {
+	var b strings.Builder
+	line, column := 0, 1
+	var base annotation.Position
+	for i, c := range doc.List {
+		pos := s.fset.Position(c.Pos())
+		if i == 0 {
+			line = pos.Line
+			base = annotation.Position{
+				Filename: pos.Filename,
+				Offset:   pos.Offset - pos.Column + 1,
+				Line:     pos.Line,
+				Column:   1,
+			}
+		}
+		for ; line < pos.Line; line++ {
+			b.WriteByte('\n')
+			column = 1
+		}
+		for ; column < pos.Column; column++ {
+			b.WriteByte(' ')
+		}
+		text := blankMarkers(c.Text)
+		b.WriteString(text)
+		line += strings.Count(text, "\n")
+		if i := strings.LastIndexByte(text, '\n'); i >= 0 {
+			column = len(text) - i
+		} else {
+			column += len(text)
+		}
+	}
+	return b.String(), base
+}
+
+// blankMarkers replaces the comment markers of a comment with spaces.
+func blankMarkers(comment string) string {
+	if strings.HasPrefix(comment, "//") {
+		return "  " + comment[2:]
+	}
+	text := "  " + strings.TrimSuffix(comment[2:], "*/") + "  "
+	lines := strings.Split(text, "\n")
+	for i, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if strings.HasPrefix(trimmed, "*") && !strings.HasPrefix(trimmed, "*/") {
+			n := len(line) - len(trimmed)
+			lines[i+1] = line[:n] + " " + trimmed[1:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
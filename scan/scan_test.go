@@ -0,0 +1,369 @@
+package scan
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-services/annotation"
+)
+
+// summary is a comparable summary of a declaration.
+type summary struct {
+	Kind        Kind
+	Name        string
+	Receiver    string
+	Parent      string
+	Line        int
+	Annotations []string
+}
+
+func summarize(declarations []Declaration) []summary {
+	var result []summary
+	for _, d := range declarations {
+		s := summary{
+			Kind:     d.Kind,
+			Name:     d.Name,
+			Receiver: d.Receiver,
+			Line:     d.Position.Line,
+		}
+		if d.Parent != nil {
+			s.Parent = string(d.Parent.Kind) + " " + d.Parent.Name
+		}
+		for _, a := range d.Annotations {
+			s.Annotations = append(s.Annotations, a.String())
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+const source = `package service
+
+// Service is the user service.
+// @Service(name="users")
+type Service struct{}
+
+// Get returns a user.
+//
+// @Get(
+//     "/users/{id}"
+// )
+// @Deprecated
+func (s *Service) Get() {}
+
+/*
+ * New creates the service.
+ * @Constructor
+ */
+func New() *Service { return nil }
+
+/* @Client() */
+type Client interface{}
+
+type (
+	// @Entity
+	User struct{}
+
+	Group struct{}
+)
+
+// @Config
+var timeout, retries = 1, 2
+
+// @Enum
+const (
+	A = iota
+	B
+)
+
+func helper() {}
+`
+
+func TestFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    []summary
+		wantErr bool
+	}{
+		{
+			name: "Should return the declarations with the annotations in their doc comments",
+			src:  source,
+			want: []summary{
+				{Kind: TYPE, Name: "Service", Line: 5, Annotations: []string{`@Service(name="users")`}},
+				{Kind: METHOD, Name: "Get", Receiver: "Service", Line: 13, Annotations: []string{`@Get(value="/users/{id}")`, "@Deprecated"}},
+				{Kind: FUNC, Name: "New", Line: 19, Annotations: []string{"@Constructor"}},
+				{Kind: INTERFACE, Name: "Client", Line: 22, Annotations: []string{"@Client()"}},
+				{Kind: TYPE, Name: "User", Line: 26, Annotations: []string{"@Entity"}},
+				{Kind: TYPE, Name: "Group", Line: 28},
+				{Kind: VAR, Name: "timeout", Line: 32, Annotations: []string{"@Config"}},
+				{Kind: VAR, Name: "retries", Line: 32, Annotations: []string{"@Config"}},
+				{Kind: CONST, Line: 35, Annotations: []string{"@Enum"}},
+				{Kind: CONST, Name: "A", Parent: "const ", Line: 36},
+				{Kind: CONST, Name: "B", Parent: "const ", Line: 37},
+				{Kind: FUNC, Name: "helper", Line: 40},
+			},
+		},
+		{
+			name:    "Should return an error if the source can not be parsed",
+			src:     "package service\nfunc {",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := File("service.go", tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("File() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if s := summarize(got); !reflect.DeepEqual(s, tt.want) {
+				t.Errorf("File() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+const members = `package model
+
+// @Table("users")
+type User[
+	// @Key
+	K comparable,
+	V any, // @Value
+] struct {
+	// @Column(name="id")
+	ID K
+	Name, Email string // @Column
+	sync.Mutex
+	Address struct {
+		City string // @Column(name="city")
+	}
+}
+
+type Repository interface {
+	// @Query("select * from users")
+	All() []User
+	Find(/* @PathParam("id") */ id int) User /* @Query("select * from users where id = ?") */
+	io.Closer
+}
+
+// @Enum
+const (
+	// @Value("a")
+	A = iota
+	B // @Value("b")
+)
+
+func Map[T any /* @Numeric */, R any](t T) R { return nil }
+
+func (u *User[K, V]) Rename(
+	// @Param("name")
+	name string,
+	force bool, // @Flag
+) {
+}
+
+func handle(/* @Body */ []byte) {}
+`
+
+func TestFile_members(t *testing.T) {
+	got, err := File("model.go", members)
+	if err != nil {
+		t.Fatalf("File() error = %v", err)
+	}
+	want := []summary{
+		{Kind: TYPE, Name: "User", Line: 4, Annotations: []string{`@Table(value="users")`}},
+		{Kind: TYPEPARAM, Name: "K", Parent: "type User", Line: 6, Annotations: []string{"@Key"}},
+		{Kind: TYPEPARAM, Name: "V", Parent: "type User", Line: 7, Annotations: []string{"@Value"}},
+		{Kind: FIELD, Name: "ID", Parent: "type User", Line: 10, Annotations: []string{`@Column(name="id")`}},
+		{Kind: FIELD, Name: "Name", Parent: "type User", Line: 11, Annotations: []string{"@Column"}},
+		{Kind: FIELD, Name: "Email", Parent: "type User", Line: 11, Annotations: []string{"@Column"}},
+		{Kind: FIELD, Name: "Mutex", Parent: "type User", Line: 12},
+		{Kind: FIELD, Name: "Address", Parent: "type User", Line: 13},
+		{Kind: FIELD, Name: "City", Parent: "field Address", Line: 14, Annotations: []string{`@Column(name="city")`}},
+		{Kind: INTERFACE, Name: "Repository", Line: 18},
+		{Kind: METHOD, Name: "All", Receiver: "Repository", Parent: "interface Repository", Line: 20, Annotations: []string{`@Query(value="select * from users")`}},
+		{Kind: METHOD, Name: "Find", Receiver: "Repository", Parent: "interface Repository", Line: 21, Annotations: []string{`@Query(value="select * from users where id = ?")`}},
+		{Kind: PARAM, Name: "id", Parent: "method Find", Line: 21, Annotations: []string{`@PathParam(value="id")`}},
+		{Kind: CONST, Line: 26, Annotations: []string{"@Enum"}},
+		{Kind: CONST, Name: "A", Parent: "const ", Line: 28, Annotations: []string{`@Value(value="a")`}},
+		{Kind: CONST, Name: "B", Parent: "const ", Line: 29, Annotations: []string{`@Value(value="b")`}},
+		{Kind: FUNC, Name: "Map", Line: 32},
+		{Kind: TYPEPARAM, Name: "T", Parent: "func Map", Line: 32, Annotations: []string{"@Numeric"}},
+		{Kind: TYPEPARAM, Name: "R", Parent: "func Map", Line: 32},
+		{Kind: PARAM, Name: "t", Parent: "func Map", Line: 32},
+		{Kind: METHOD, Name: "Rename", Receiver: "User", Line: 34},
+		{Kind: PARAM, Name: "name", Parent: "method Rename", Line: 36, Annotations: []string{`@Param(value="name")`}},
+		{Kind: PARAM, Name: "force", Parent: "method Rename", Line: 37, Annotations: []string{"@Flag"}},
+		{Kind: FUNC, Name: "handle", Line: 41},
+		{Kind: PARAM, Parent: "func handle", Line: 41, Annotations: []string{"@Body"}},
+	}
+	if s := summarize(got); !reflect.DeepEqual(s, want) {
+		t.Errorf("File() = %v, want %v", s, want)
+	}
+}
+
+func TestFile_options(t *testing.T) {
+	src := "package service\n\n// @Get(path=\"/users/{id}/groups\")\n// @Deprecated\nfunc Get() {}\n\n// @Internal\nfunc helper() {}\n"
+	tests := []struct {
+		name    string
+		options []annotation.Option
+		want    []summary
+		wantErr string
+	}{
+		{
+			name: "Should find every annotation without options",
+			want: []summary{
+				{Kind: FUNC, Name: "Get", Line: 5, Annotations: []string{`@Get(path="/users/{id}/groups")`, "@Deprecated"}},
+				{Kind: FUNC, Name: "helper", Line: 8, Annotations: []string{"@Internal"}},
+			},
+		},
+		{
+			name:    "Should report the annotations after the limit of every comment",
+			options: []annotation.Option{annotation.MaxAnnotations(1)},
+			want: []summary{
+				{Kind: FUNC, Name: "Get", Line: 5, Annotations: []string{`@Get(path="/users/{id}/groups")`}},
+				{Kind: FUNC, Name: "helper", Line: 8, Annotations: []string{"@Internal"}},
+			},
+			wantErr: "service.go:4:4: there are more than 1 annotations",
+		},
+		{
+			name:    "Should report the annotations that are longer than the limit",
+			options: []annotation.Option{annotation.MaxLength(16)},
+			want: []summary{
+				{Kind: FUNC, Name: "Get", Line: 5, Annotations: []string{"@Deprecated"}},
+				{Kind: FUNC, Name: "helper", Line: 8, Annotations: []string{"@Internal"}},
+			},
+			wantErr: "service.go:3:4: annotation is longer than 16 bytes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := File("service.go", src, tt.options...)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tt.wantErr {
+				t.Errorf("File() error = %v, want %v", gotErr, tt.wantErr)
+			}
+			if s := summarize(got); !reflect.DeepEqual(s, tt.want) {
+				t.Errorf("File() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestFile_errors(t *testing.T) {
+	src := "package service\n\n// Get returns a user, see a@b.com.\n// @Get(path=\"/users\", path=\"/x\")\n// @Auth(roles=[\"admin\"\n// @Deprecated\nfunc Get() {}\n"
+	got, err := File("service.go", src)
+	want := []summary{
+		{Kind: FUNC, Name: "Get", Line: 7, Annotations: []string{"@Deprecated"}},
+	}
+	if s := summarize(got); !reflect.DeepEqual(s, want) {
+		t.Errorf("File() = %v, want %v", s, want)
+	}
+	var errs annotation.ErrorList
+	if !errors.As(err, &errs) {
+		t.Fatalf("File() error = %v, want an annotation.ErrorList", err)
+	}
+	var messages []string
+	for _, e := range errs {
+		messages = append(messages, e.Error())
+	}
+	wantMessages := []string{
+		"service.go:4:24: duplicate parameter `path` in `@Get()` Annotation, first set at service.go:4:9",
+		"service.go:5:24: unexpected end of input, expected `,` or `]`",
+	}
+	if !reflect.DeepEqual(messages, wantMessages) {
+		t.Errorf("File() errors = %v, want %v", messages, wantMessages)
+	}
+}
+
+func TestDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"b.go":      "package service\n\n// @B\nfunc B() {}\n",
+		"a.go":      "package service\n\n// @A\nfunc A() {}\n",
+		"a_test.go": "package service\n\n// @Test\nfunc TestA() {}\n",
+		"README.md": "@Readme()",
+	}
+	for name, src := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := Dir(dir)
+	if err != nil {
+		t.Fatalf("Dir() error = %v", err)
+	}
+	want := []summary{
+		{Kind: FUNC, Name: "A", Line: 4, Annotations: []string{"@A"}},
+		{Kind: FUNC, Name: "B", Line: 4, Annotations: []string{"@B"}},
+	}
+	if s := summarize(got); !reflect.DeepEqual(s, want) {
+		t.Errorf("Dir() = %v, want %v", s, want)
+	}
+	got, err = Dir(dir, annotation.Strict())
+	var errs annotation.ErrorList
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Errorf("Dir() error = %v, want the errors of @A and @B", err)
+	}
+	want = []summary{
+		{Kind: FUNC, Name: "A", Line: 4},
+		{Kind: FUNC, Name: "B", Line: 4},
+	}
+	if s := summarize(got); !reflect.DeepEqual(s, want) {
+		t.Errorf("Dir() with options = %v, want %v", s, want)
+	}
+	if _, err := Dir(filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("Dir() should return an error if the directory does not exist")
+	}
+}
+
+func TestFile_positions(t *testing.T) {
+	src := "package service\n\n// Get returns a user.\n//\t@Get(\n//\t\tpath=\"/users\"\n//\t)\nfunc Get() {}\n\ntype T struct {\n\tID int /* @Column(name=\"id\") */\n}\n"
+	got, err := File("service.go", src)
+	if err != nil {
+		t.Fatalf("File() error = %v", err)
+	}
+	get := got[0].Annotations[0]
+	tests := []struct {
+		name string
+		got  annotation.Position
+		text string
+	}{
+		{name: "annotation start", got: get.Range().Start, text: "@Get("},
+		{name: "key start", got: get.KeyRange("path").Start, text: "path="},
+		{name: "value start", got: get.Get("path").Range().Start, text: "\"/users\""},
+		{name: "field annotation start", got: got[2].Annotations[0].Range().Start, text: "@Column("},
+		{name: "field annotation value", got: got[2].Annotations[0].Get("name").Range().Start, text: "\"id\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.Filename != "service.go" {
+				t.Errorf("Filename = %s, want service.go", tt.got.Filename)
+			}
+			if !strings.HasPrefix(src[tt.got.Offset:], tt.text) {
+				t.Errorf("Offset %d points to %q, want %q", tt.got.Offset, src[tt.got.Offset:], tt.text)
+			}
+			lineStart := strings.LastIndexByte(src[:tt.got.Offset], '\n') + 1
+			if line := strings.Count(src[:tt.got.Offset], "\n") + 1; tt.got.Line != line {
+				t.Errorf("Line = %d, want %d", tt.got.Line, line)
+			}
+			if column := tt.got.Offset - lineStart + 1; tt.got.Column != column {
+				t.Errorf("Column = %d, want %d", tt.got.Column, column)
+			}
+		})
+	}
+}